@@ -0,0 +1,73 @@
+package jwt
+
+import (
+	"encoding/json"
+	"math"
+	"time"
+)
+
+//Audience is the aud claim: it is encoded as a JSON array and decodes both a string and an array of strings (RFC 7519)
+type Audience []string
+
+//UnmarshalJSON accepts a single audience or a list
+func (a *Audience) UnmarshalJSON(b []byte) error {
+	var single string
+	if err := json.Unmarshal(b, &single); err == nil {
+		*a = Audience{single}
+		if single == "" {
+			*a = nil
+		}
+		return nil
+	}
+	var list []string
+	if err := json.Unmarshal(b, &list); err != nil {
+		return err
+	}
+	*a = Audience(list)
+	return nil
+}
+
+//Contains checks if aud is one of the audiences
+func (a Audience) Contains(aud string) bool {
+	for _, v := range a {
+		if v == aud {
+			return true
+		}
+	}
+	return false
+}
+
+//Claims are the registered claims of a token (RFC 7519). IssuedAt has a microsecond precision: revoking the tokens of an account does not revoke the tokens issued later in the same second
+type Claims struct {
+	Audience  Audience `json:"aud,omitempty"`
+	ExpiresAt int64    `json:"exp,omitempty"`
+	Id        string   `json:"jti,omitempty"`
+	IssuedAt  float64  `json:"iat,omitempty"`
+	Issuer    string   `json:"iss,omitempty"`
+	NotBefore int64    `json:"nbf,omitempty"`
+	Subject   string   `json:"sub,omitempty"`
+}
+
+//IssuedAtTime returns the iat claim as a time, with a microsecond precision
+func (c *Claims) IssuedAtTime() time.Time {
+	return time.Unix(0, int64(math.Round(c.IssuedAt*1e6))*int64(time.Microsecond))
+}
+
+//numericDate returns t as a NumericDate with a microsecond precision (RFC 7519)
+func numericDate(t time.Time) float64 {
+	return float64(t.UnixNano()/int64(time.Microsecond)) / 1e6
+}
+
+//TokenParams holds the values specific to a generated token
+type TokenParams struct {
+	//ID is the token ID (jti claim). A random ID is used if empty
+	ID string
+	//Audiences the token is issued for. The handler's audience is used if empty
+	Audiences []string
+	//Delay before the token is valid (nbf claim)
+	Delay time.Duration
+	//Extra claims added to the account info
+	Extra *ExtraClaims
+	//NotAfter caps the expiry of the token when it is before the end of its validity
+	NotAfter time.Time
+}
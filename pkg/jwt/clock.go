@@ -0,0 +1,57 @@
+package jwt
+
+import (
+	"sync"
+	"time"
+)
+
+//Clock returns the current time. It is used to validate tokens and to manage keys and revocations
+type Clock interface {
+	Now() time.Time
+}
+
+//Clocked is implemented by types reading the time from an injectable Clock
+type Clocked interface {
+	SetClock(clock Clock)
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+//SystemClock reads the wall clock
+var SystemClock Clock = systemClock{}
+
+//FakeClock is a Clock that only moves when told to (for tests)
+type FakeClock struct {
+	mu sync.RWMutex
+	t  time.Time
+}
+
+//NewFakeClock returns a FakeClock set at t
+func NewFakeClock(t time.Time) *FakeClock {
+	return &FakeClock{t: t}
+}
+
+//Now returns the time of the clock
+func (c *FakeClock) Now() time.Time {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.t
+}
+
+//Advance moves the clock forward by d
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.t = c.t.Add(d)
+}
+
+//Set the time of the clock
+func (c *FakeClock) Set(t time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.t = t
+}
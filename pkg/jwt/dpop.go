@@ -0,0 +1,245 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+)
+
+//DPoPHeader is the HTTP header holding DPoP proofs
+const DPoPHeader = "DPoP"
+
+const dpopType = "dpop+jwt"
+
+//DPoP errors (RFC 9449)
+var (
+	ErrInvalidDPoPProof = fmt.Errorf("invalid dpop proof")
+	ErrDPoPReplayed     = fmt.Errorf("dpop proof replayed")
+	ErrDPoPKeyMismatch  = fmt.Errorf("token not bound to the dpop proof key")
+)
+
+//ReplayCache remembers the IDs of DPoP proofs until they can not be accepted anymore
+type ReplayCache interface {
+	//Use records id until exp and returns false if it was already used
+	Use(id string, exp time.Time) (bool, error)
+}
+
+//MemReplayCache is an in-memory ReplayCache
+type MemReplayCache struct {
+	mu    sync.Mutex
+	ids   map[string]time.Time
+	clock Clock
+}
+
+//NewMemReplayCache returns an empty in-memory ReplayCache
+func NewMemReplayCache() *MemReplayCache {
+	return &MemReplayCache{ids: map[string]time.Time{}, clock: SystemClock}
+}
+
+//SetClock replaces the wall clock used to drop expired entries
+func (m *MemReplayCache) SetClock(clock Clock) {
+	if clock == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.clock = clock
+}
+
+//Use records a proof ID
+func (m *MemReplayCache) Use(id string, exp time.Time) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := m.clock.Now()
+	for k, e := range m.ids {
+		if !now.Before(e) {
+			delete(m.ids, k)
+		}
+	}
+	if _, ok := m.ids[id]; ok {
+		return false, nil
+	}
+	m.ids[id] = exp
+	return true, nil
+}
+
+//dpopClaims are the claims of a DPoP proof
+type dpopClaims struct {
+	Jti string `json:"jti"`
+	Htm string `json:"htm"`
+	Htu string `json:"htu"`
+	Iat int64  `json:"iat"`
+	Ath string `json:"ath,omitempty"`
+}
+
+//Valid to implement jwt.Claims interface. Proofs are checked by DPoPVerifier
+func (c *dpopClaims) Valid() error {
+	return nil
+}
+
+//DPoPProof is a verified DPoP proof
+type DPoPProof struct {
+	//Jkt is the thumbprint of the proof key
+	Jkt string
+	ID  string
+	//IssuedAt is the time the proof was created
+	IssuedAt time.Time
+}
+
+//DPoPVerifier checks DPoP proofs (RFC 9449): their signature with the embedded public key, the request they were issued for, their age and that they are not replayed
+type DPoPVerifier struct {
+	cache  ReplayCache
+	clock  Clock
+	maxAge time.Duration
+	leeway time.Duration
+}
+
+//NewDPoPVerifier returns a DPoPVerifier accepting proofs issued at most maxAge ago. cache defaults to an in-memory ReplayCache
+func NewDPoPVerifier(cache ReplayCache, maxAge time.Duration) *DPoPVerifier {
+	if cache == nil {
+		cache = NewMemReplayCache()
+	}
+	if maxAge < 0 {
+		maxAge *= -1
+	}
+	return &DPoPVerifier{cache: cache, clock: SystemClock, maxAge: maxAge}
+}
+
+//SetClock replaces the wall clock used to check the age of proofs. It is also set on the cache if it is Clocked
+func (v *DPoPVerifier) SetClock(clock Clock) {
+	if clock == nil {
+		return
+	}
+	v.clock = clock
+	if c, ok := v.cache.(Clocked); ok {
+		c.SetClock(clock)
+	}
+}
+
+//SetLeeway sets the clock skew tolerated on proofs issued in the future
+func (v *DPoPVerifier) SetLeeway(leeway time.Duration) {
+	if leeway < 0 {
+		leeway *= -1
+	}
+	v.leeway = leeway
+}
+
+//Verify a proof sent with a request of method to uri. accessToken is the token presented with the proof, if any: the proof must hold its hash (ath claim)
+func (v *DPoPVerifier) Verify(proof, method, uri, accessToken string) (*DPoPProof, error) {
+	var jwk *JWK
+	keyFunc := func(token *jwt.Token) (interface{}, error) {
+		if typ, _ := token.Header["typ"].(string); typ != dpopType {
+			return nil, ErrInvalidDPoPProof
+		}
+		raw, ok := token.Header["jwk"].(map[string]interface{})
+		if !ok {
+			return nil, ErrInvalidDPoPProof
+		}
+		if _, ok = raw["d"]; ok {
+			return nil, ErrInvalidDPoPProof
+		}
+		b, err := json.Marshal(raw)
+		if err != nil {
+			return nil, ErrInvalidDPoPProof
+		}
+		jwk = &JWK{}
+		if err = json.Unmarshal(b, jwk); err != nil {
+			return nil, ErrInvalidDPoPProof
+		}
+		key, err := jwk.PublicKey()
+		if err != nil {
+			return nil, ErrInvalidDPoPProof
+		}
+		alg, err := signingMethodForKey(key)
+		if err != nil || token.Method == nil || token.Method.Alg() != alg.Alg() {
+			return nil, ErrInvalidDPoPProof
+		}
+		return key, nil
+	}
+	c := &dpopClaims{}
+	if _, err := jwt.ParseWithClaims(proof, c, keyFunc); err != nil {
+		return nil, ErrInvalidDPoPProof
+	}
+	if c.Jti == "" || c.Htm != method || !sameURI(c.Htu, uri) {
+		return nil, ErrInvalidDPoPProof
+	}
+	if accessToken != "" {
+		sum := sha256.Sum256([]byte(accessToken))
+		if c.Ath != b64(sum[:]) {
+			return nil, ErrInvalidDPoPProof
+		}
+	}
+	now := v.clock.Now()
+	iat := time.Unix(c.Iat, 0)
+	if iat.After(now.Add(v.leeway)) || now.Sub(iat) > v.maxAge {
+		return nil, ErrInvalidDPoPProof
+	}
+	jkt, err := jwk.Thumbprint()
+	if err != nil {
+		return nil, ErrInvalidDPoPProof
+	}
+	fresh, err := v.cache.Use(jkt+":"+c.Jti, iat.Add(v.maxAge+v.leeway))
+	if err != nil {
+		return nil, err
+	}
+	if !fresh {
+		return nil, ErrDPoPReplayed
+	}
+	return &DPoPProof{Jkt: jkt, ID: c.Jti, IssuedAt: iat}, nil
+}
+
+//NewDPoPProof returns a proof signed by key for a request of method to uri, presenting accessToken if not empty. It is meant for clients and tests
+func NewDPoPProof(key interface{}, method, uri, accessToken string, t time.Time) (string, error) {
+	signer, ok := key.(interface{ Public() crypto.PublicKey })
+	if !ok {
+		return "", errUnsupportedKeyType
+	}
+	jwk, err := NewJWK("", signer.Public())
+	if err != nil {
+		return "", err
+	}
+	signingMethod, err := signingMethodForKey(key)
+	if err != nil {
+		return "", err
+	}
+	c := &dpopClaims{Jti: NewTokenID(), Htm: method, Htu: uri, Iat: t.Unix()}
+	if accessToken != "" {
+		sum := sha256.Sum256([]byte(accessToken))
+		c.Ath = b64(sum[:])
+	}
+	token := jwt.NewWithClaims(signingMethod, c)
+	token.Header["typ"] = dpopType
+	token.Header["jwk"] = &JWK{Kty: jwk.Kty, Crv: jwk.Crv, N: jwk.N, E: jwk.E, X: jwk.X, Y: jwk.Y}
+	return token.SignedString(key)
+}
+
+//ConfirmDPoP checks that a token is bound to the key of a verified proof
+func ConfirmDPoP(claims *AccessToken, proof *DPoPProof) error {
+	if claims == nil || proof == nil || claims.Extra == nil || claims.Extra.Confirmation == nil {
+		return ErrDPoPKeyMismatch
+	}
+	if claims.Extra.Confirmation.Jkt == "" || claims.Extra.Confirmation.Jkt != proof.Jkt {
+		return ErrDPoPKeyMismatch
+	}
+	return nil
+}
+
+//sameURI compares the htu claim with the request URI, ignoring query and fragment (RFC 9449 section 4.3)
+func sameURI(htu, uri string) bool {
+	a, err := url.Parse(htu)
+	if err != nil {
+		return false
+	}
+	b, err := url.Parse(uri)
+	if err != nil {
+		return false
+	}
+	return strings.EqualFold(a.Scheme, b.Scheme) && strings.EqualFold(a.Host, b.Host) && a.EscapedPath() == b.EscapedPath()
+}
@@ -0,0 +1,58 @@
+package jwt
+
+import (
+	"fmt"
+
+	"github.com/dgrijalva/jwt-go"
+)
+
+//Validation errors returned by Handler.Validate
+var (
+	ErrMalformed     = fmt.Errorf("malformed token")
+	ErrExpired       = fmt.Errorf("token expired")
+	ErrNotValidYet   = fmt.Errorf("token not valid yet")
+	ErrBadSignature  = fmt.Errorf("invalid token signature")
+	ErrUnknownKey    = fmt.Errorf("unknown key id")
+	ErrWrongAudience = fmt.Errorf("token not issued for this audience")
+	ErrWrongIssuer   = fmt.Errorf("token not issued by a trusted issuer")
+	ErrWrongSubject  = fmt.Errorf("token not issued for this purpose")
+	ErrRevoked       = fmt.Errorf("token revoked")
+)
+
+//validationError translates errors returned by jwt.ParseWithClaims into the errors of this package
+func validationError(err error) error {
+	ve, ok := err.(*jwt.ValidationError)
+	if !ok {
+		return ErrMalformed
+	}
+	switch ve.Inner {
+	case ErrUnknownKey, errMissingKey, errKeyExpired:
+		return ErrUnknownKey
+	case errUnexpectedAlg:
+		return ErrBadSignature
+	case ErrMalformed, ErrExpired, ErrNotValidYet:
+		return ve.Inner
+	}
+	switch {
+	case ve.Errors&jwt.ValidationErrorMalformed != 0:
+		return ErrMalformed
+	case ve.Errors&jwt.ValidationErrorUnverifiable != 0:
+		return ErrUnknownKey
+	case ve.Errors&jwt.ValidationErrorSignatureInvalid != 0:
+		return ErrBadSignature
+	case ve.Errors&jwt.ValidationErrorExpired != 0:
+		return ErrExpired
+	case ve.Errors&(jwt.ValidationErrorNotValidYet|jwt.ValidationErrorIssuedAt) != 0:
+		return ErrNotValidYet
+	}
+	return ErrMalformed
+}
+
+//keyError translates errors returned by a jwt.Keyfunc into the errors of this package
+func keyError(err error) error {
+	switch err {
+	case ErrUnknownKey, errMissingKey, errKeyExpired:
+		return ErrUnknownKey
+	}
+	return ErrBadSignature
+}
@@ -0,0 +1,133 @@
+package jwt
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+//Authentication methods (amr claim values, RFC 8176)
+const (
+	AuthMethodPassword = "pwd"
+	AuthMethodMFA      = "mfa"
+)
+
+var (
+	errEmptyClaimName      = fmt.Errorf("claim name is empty")
+	errClaimRegistered     = fmt.Errorf("claim already registered")
+	errUnregisteredClaim   = fmt.Errorf("unregistered claim")
+	errInvalidClaimExample = fmt.Errorf("claim example is nil")
+)
+
+//Scopes is the scope claim: a list of scopes encoded as a space separated string (RFC 8693)
+type Scopes []string
+
+//MarshalJSON encodes scopes as a space separated string
+func (s Scopes) MarshalJSON() ([]byte, error) {
+	return json.Marshal(strings.Join(s, " "))
+}
+
+//UnmarshalJSON decodes a space separated string of scopes
+func (s *Scopes) UnmarshalJSON(b []byte) error {
+	var str string
+	if err := json.Unmarshal(b, &str); err != nil {
+		return err
+	}
+	*s = Scopes(strings.Fields(str))
+	if len(*s) == 0 {
+		*s = nil
+	}
+	return nil
+}
+
+//Contains checks if scope is one of the scopes
+func (s Scopes) Contains(scope string) bool {
+	for _, v := range s {
+		if v == scope {
+			return true
+		}
+	}
+	return false
+}
+
+//ExtraClaims extend the account info of a token. ClientID is the client the token was issued to (RFC 9068). App holds application specific claims, whose names must be registered in the handler's ClaimsSchema
+type ExtraClaims struct {
+	Tenant       string                 `json:"tid,omitempty"`
+	SessionID    string                 `json:"sid,omitempty"`
+	ClientID     string                 `json:"client_id,omitempty"`
+	AuthMethods  []string               `json:"amr,omitempty"`
+	Scopes       Scopes                 `json:"scope,omitempty"`
+	Confirmation *Confirmation          `json:"cnf,omitempty"`
+	Actor        *Actor                 `json:"act,omitempty"`
+	App          map[string]interface{} `json:"app,omitempty"`
+}
+
+//Confirmation binds a token to a key (cnf claim, RFC 7800). Jkt is the thumbprint of the DPoP key (RFC 9449)
+type Confirmation struct {
+	Jkt string `json:"jkt,omitempty"`
+}
+
+//Actor is the party acting on behalf of the subject of a token (act claim, RFC 8693). Act is the previous actor of a delegation chain
+type Actor struct {
+	Sub string `json:"sub"`
+	Act *Actor `json:"act,omitempty"`
+}
+
+//ClaimsSchema registers the names and types of application specific claims. Tokens holding unregistered claims are rejected
+type ClaimsSchema struct {
+	mu    sync.RWMutex
+	types map[string]reflect.Type
+}
+
+//NewClaimsSchema returns an empty ClaimsSchema
+func NewClaimsSchema() *ClaimsSchema {
+	return &ClaimsSchema{types: map[string]reflect.Type{}}
+}
+
+//Register an application specific claim: its values are decoded to the type of example
+func (s *ClaimsSchema) Register(name string, example interface{}) error {
+	if name == "" {
+		return errEmptyClaimName
+	}
+	if example == nil {
+		return errInvalidClaimExample
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.types[name]; ok {
+		return errClaimRegistered
+	}
+	s.types[name] = reflect.TypeOf(example)
+	return nil
+}
+
+//decode converts the application specific claims to their registered types
+func (s *ClaimsSchema) decode(app map[string]interface{}) (map[string]interface{}, error) {
+	if len(app) == 0 {
+		return nil, nil
+	}
+	if s == nil {
+		return nil, errUnregisteredClaim
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	res := make(map[string]interface{}, len(app))
+	for name, v := range app {
+		t, ok := s.types[name]
+		if !ok {
+			return nil, errUnregisteredClaim
+		}
+		b, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		ptr := reflect.New(t)
+		if err = json.Unmarshal(b, ptr.Interface()); err != nil {
+			return nil, err
+		}
+		res[name] = ptr.Elem().Interface()
+	}
+	return res, nil
+}
@@ -0,0 +1,59 @@
+package jwt
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+)
+
+//Format of the tokens issued by a Handler
+type Format string
+
+//Supported token formats
+const (
+	FormatJWT          Format = "jwt"
+	FormatPasetoLocal  Format = "v4.local"
+	FormatPasetoPublic Format = "v4.public"
+)
+
+var errUnknownFormat = fmt.Errorf("unknown token format")
+
+//NewSymmetricHandler returns a Handler for symmetric keys in the given format: HS256 signed JWT or v4.local PASETO tokens (32 bytes keys)
+func NewSymmetricHandler(format Format, issuer, audience, subject string, picker KeyPicker, keyFunc jwt.Keyfunc, stdFunc StdClaimsFunc, customFunc CustomClaimsFunc, validity time.Duration) (Handler, error) {
+	switch format {
+	case FormatJWT:
+		h, err := NewSimpleHandler(issuer, audience, subject, picker, keyFunc, stdFunc, customFunc, validity)
+		if err != nil {
+			return nil, err
+		}
+		return h, nil
+	case FormatPasetoLocal:
+		h, err := NewPasetoLocalHandler(issuer, audience, subject, picker, keyFunc, stdFunc, customFunc, validity)
+		if err != nil {
+			return nil, err
+		}
+		return h, nil
+	}
+	return nil, errUnknownFormat
+}
+
+//NewPublicKeyHandler returns a Handler for asymmetric keys in the given format: JWT signed with RSA, ECDSA or Ed25519 keys, or v4.public PASETO tokens (Ed25519 keys).
+//picker can be nil for services that only verify tokens
+func NewPublicKeyHandler(format Format, issuer, audience, subject string, picker SignerPicker, keyFunc jwt.Keyfunc, stdFunc StdClaimsFunc, customFunc CustomClaimsFunc, validity time.Duration) (Handler, error) {
+	switch format {
+	case FormatJWT:
+		h, err := NewAsymmetricHandler(issuer, audience, subject, picker, keyFunc, stdFunc, customFunc, validity)
+		if err != nil {
+			return nil, err
+		}
+		return h, nil
+	case FormatPasetoPublic:
+		h, err := NewPasetoPublicHandler(issuer, audience, subject, picker, keyFunc, stdFunc, customFunc, validity)
+		if err != nil {
+			return nil, err
+		}
+		return h, nil
+	}
+	return nil, errUnknownFormat
+}
@@ -0,0 +1,293 @@
+package jwt
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	pb "github.com/klahssen/authn/proto-gen/accounts/apiv1"
+)
+
+//JWE key management algorithms (RFC 7518). Content is always encrypted with A256GCM
+const (
+	AlgDir     = "dir"
+	AlgRSAOAEP = "RSA-OAEP-256"
+	EncA256GCM = "A256GCM"
+)
+
+const (
+	aesKeySize   = 32
+	rsaMinBits   = 2048
+	jweHeaderCty = "JWT"
+)
+
+var (
+	errNoEncryptionKey    = fmt.Errorf("no encryption key")
+	errInvalidEncryptKey  = fmt.Errorf("encryption keys must be 32 bytes or RSA private keys of at least 2048 bits")
+	errFailedToEncryptJWE = fmt.Errorf("failed to encrypt token")
+)
+
+//jweHeader is the protected header of compact JWE tokens
+type jweHeader struct {
+	Alg string `json:"alg"`
+	Enc string `json:"enc"`
+	Kid string `json:"kid"`
+	Cty string `json:"cty,omitempty"`
+}
+
+//EncryptionKeys holds the keys encrypting tokens, by key ID: 32 bytes keys are used directly with A256GCM (dir), RSA private keys wrap a random content key (RSA-OAEP-256).
+//Tokens are encrypted with the current key and decrypted with the key named in their header
+type EncryptionKeys struct {
+	mu      sync.RWMutex
+	keys    map[string]interface{}
+	current string
+}
+
+//NewEncryptionKeys returns an empty set of encryption keys
+func NewEncryptionKeys() *EncryptionKeys {
+	return &EncryptionKeys{keys: map[string]interface{}{}}
+}
+
+//Add a []byte or *rsa.PrivateKey key. The first key added becomes the current key
+func (ek *EncryptionKeys) Add(kid string, key interface{}) error {
+	if kid == "" {
+		return errEmptyKeyID
+	}
+	switch k := key.(type) {
+	case []byte:
+		if len(k) != aesKeySize {
+			return errInvalidEncryptKey
+		}
+		key = append([]byte(nil), k...)
+	case *rsa.PrivateKey:
+		if k.N.BitLen() < rsaMinBits {
+			return errInvalidEncryptKey
+		}
+	default:
+		return errInvalidEncryptKey
+	}
+	ek.mu.Lock()
+	defer ek.mu.Unlock()
+	ek.keys[kid] = key
+	if ek.current == "" {
+		ek.current = kid
+	}
+	return nil
+}
+
+//SetCurrent selects the key encrypting new tokens
+func (ek *EncryptionKeys) SetCurrent(kid string) error {
+	ek.mu.Lock()
+	defer ek.mu.Unlock()
+	if _, ok := ek.keys[kid]; !ok {
+		return errMissingKey
+	}
+	ek.current = kid
+	return nil
+}
+
+//Remove a key: tokens it encrypted can not be decrypted anymore
+func (ek *EncryptionKeys) Remove(kid string) {
+	ek.mu.Lock()
+	defer ek.mu.Unlock()
+	delete(ek.keys, kid)
+	if ek.current == kid {
+		ek.current = ""
+	}
+}
+
+func (ek *EncryptionKeys) currentKey() (string, interface{}, error) {
+	ek.mu.RLock()
+	defer ek.mu.RUnlock()
+	key, ok := ek.keys[ek.current]
+	if !ok {
+		return "", nil, errNoEncryptionKey
+	}
+	return ek.current, key, nil
+}
+
+func (ek *EncryptionKeys) key(kid string) (interface{}, bool) {
+	ek.mu.RLock()
+	defer ek.mu.RUnlock()
+	key, ok := ek.keys[kid]
+	return key, ok
+}
+
+//JWEHandler wraps a Handler and encrypts the tokens it issues (nested JWT, RFC 7516 compact serialization), so that their claims are opaque to clients
+type JWEHandler struct {
+	inner Handler
+	keys  *EncryptionKeys
+}
+
+//NewJWEHandler returns a Handler encrypting the tokens of inner with keys
+func NewJWEHandler(inner Handler, keys *EncryptionKeys) (*JWEHandler, error) {
+	if inner == nil {
+		return nil, fmt.Errorf("inner handler is nil")
+	}
+	if keys == nil {
+		return nil, fmt.Errorf("encryption keys are nil")
+	}
+	return &JWEHandler{inner: inner, keys: keys}, nil
+}
+
+//Generate returns an encrypted token: delay is used in not before, t is used for issued at and validity is read from inner value
+func (h *JWEHandler) Generate(custom *pb.Info, t time.Time, delay time.Duration) (string, error) {
+	return h.GenerateWithParams(custom, t, &TokenParams{Delay: delay})
+}
+
+//GenerateWithParams returns an encrypted token with the token ID, audiences, delay and extra claims of params
+func (h *JWEHandler) GenerateWithParams(custom *pb.Info, t time.Time, params *TokenParams) (string, error) {
+	token, err := h.inner.GenerateWithParams(custom, t, params)
+	if err != nil {
+		return "", err
+	}
+	return h.encrypt([]byte(token))
+}
+
+//Validate decrypts a token and validates it with the inner handler
+func (h *JWEHandler) Validate(token string) (*AccessToken, error) {
+	plaintext, err := h.decrypt(token)
+	if err != nil {
+		return nil, err
+	}
+	return h.inner.Validate(string(plaintext))
+}
+
+//Validity of generated tokens
+func (h *JWEHandler) Validity() time.Duration {
+	return h.inner.Validity()
+}
+
+//SetRevocationStore sets the revocation store of the inner handler, if it is Revocable
+func (h *JWEHandler) SetRevocationStore(store RevocationStore) {
+	if r, ok := h.inner.(Revocable); ok {
+		r.SetRevocationStore(store)
+	}
+}
+
+//SetClock sets the clock of the inner handler, if it is Clocked
+func (h *JWEHandler) SetClock(clock Clock) {
+	if c, ok := h.inner.(Clocked); ok {
+		c.SetClock(clock)
+	}
+}
+
+//AcceptAudiences adds audiences accepted by the inner handler, if it is an AudienceAcceptor
+func (h *JWEHandler) AcceptAudiences(audiences ...string) {
+	if a, ok := h.inner.(AudienceAcceptor); ok {
+		a.AcceptAudiences(audiences...)
+	}
+}
+
+func (h *JWEHandler) encrypt(plaintext []byte) (string, error) {
+	kid, key, err := h.keys.currentKey()
+	if err != nil {
+		return "", err
+	}
+	header := &jweHeader{Enc: EncA256GCM, Kid: kid, Cty: jweHeaderCty}
+	var cek, encryptedKey []byte
+	switch k := key.(type) {
+	case []byte:
+		header.Alg = AlgDir
+		cek = k
+	case *rsa.PrivateKey:
+		header.Alg = AlgRSAOAEP
+		cek = make([]byte, aesKeySize)
+		if _, err = rand.Read(cek); err != nil {
+			return "", errFailedToEncryptJWE
+		}
+		if encryptedKey, err = rsa.EncryptOAEP(sha256.New(), rand.Reader, &k.PublicKey, cek, nil); err != nil {
+			return "", errFailedToEncryptJWE
+		}
+	}
+	hb, err := json.Marshal(header)
+	if err != nil {
+		return "", errFailedToEncryptJWE
+	}
+	protected := base64.RawURLEncoding.EncodeToString(hb)
+	gcm, err := newGCM(cek)
+	if err != nil {
+		return "", errFailedToEncryptJWE
+	}
+	iv := make([]byte, gcm.NonceSize())
+	if _, err = rand.Read(iv); err != nil {
+		return "", errFailedToEncryptJWE
+	}
+	sealed := gcm.Seal(nil, iv, plaintext, []byte(protected))
+	ciphertext, tag := sealed[:len(sealed)-gcm.Overhead()], sealed[len(sealed)-gcm.Overhead():]
+	parts := []string{protected}
+	for _, b := range [][]byte{encryptedKey, iv, ciphertext, tag} {
+		parts = append(parts, base64.RawURLEncoding.EncodeToString(b))
+	}
+	return strings.Join(parts, "."), nil
+}
+
+func (h *JWEHandler) decrypt(token string) ([]byte, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 5 {
+		return nil, ErrMalformed
+	}
+	decoded := make([][]byte, len(parts))
+	for i, p := range parts {
+		b, err := base64.RawURLEncoding.DecodeString(p)
+		if err != nil {
+			return nil, ErrMalformed
+		}
+		decoded[i] = b
+	}
+	header := &jweHeader{}
+	if err := json.Unmarshal(decoded[0], header); err != nil {
+		return nil, ErrMalformed
+	}
+	if header.Enc != EncA256GCM {
+		return nil, ErrMalformed
+	}
+	key, ok := h.keys.key(header.Kid)
+	if !ok {
+		return nil, ErrUnknownKey
+	}
+	var cek []byte
+	switch k := key.(type) {
+	case []byte:
+		if header.Alg != AlgDir || len(decoded[1]) != 0 {
+			return nil, ErrBadSignature
+		}
+		cek = k
+	case *rsa.PrivateKey:
+		if header.Alg != AlgRSAOAEP {
+			return nil, ErrBadSignature
+		}
+		var err error
+		if cek, err = rsa.DecryptOAEP(sha256.New(), rand.Reader, k, decoded[1], nil); err != nil {
+			return nil, ErrBadSignature
+		}
+	}
+	gcm, err := newGCM(cek)
+	if err != nil || len(decoded[2]) != gcm.NonceSize() {
+		return nil, ErrBadSignature
+	}
+	plaintext, err := gcm.Open(nil, decoded[2], append(decoded[3], decoded[4]...), []byte(parts[0]))
+	if err != nil {
+		return nil, ErrBadSignature
+	}
+	return plaintext, nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	if len(key) != aesKeySize {
+		return nil, errInvalidEncryptKey
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,348 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sort"
+	"sync"
+
+	"github.com/dgrijalva/jwt-go"
+	"golang.org/x/crypto/ed25519"
+)
+
+//JWKSPath is the well known path where a KeySet is published
+const JWKSPath = "/.well-known/jwks.json"
+
+var (
+	errEmptyKeyID  = fmt.Errorf("key id is empty")
+	errMissingKey  = fmt.Errorf("token has no kid header")
+	errInvalidJWK  = fmt.Errorf("invalid jwk")
+	errNotSignable = fmt.Errorf("key can not sign: public key only")
+)
+
+//JWK is the JSON representation of a public key (RFC 7517)
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use,omitempty"`
+	Alg string `json:"alg,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+}
+
+//JWKS is a JSON Web Key Set document
+type JWKS struct {
+	Keys []*JWK `json:"keys"`
+}
+
+//KeySet holds asymmetric keys indexed by key ID. It is the single source for signing (Picker) and verifying (KeyFunc) tokens, and publishes the public keys as a JWKS document
+type KeySet struct {
+	mu      sync.RWMutex
+	private map[string]crypto.Signer
+	public  map[string]crypto.PublicKey
+	signing string
+}
+
+//NewKeySet returns an empty KeySet
+func NewKeySet() *KeySet {
+	return &KeySet{private: map[string]crypto.Signer{}, public: map[string]crypto.PublicKey{}}
+}
+
+//Add a private key to the set. The first key added becomes the signing key
+func (ks *KeySet) Add(kid string, key crypto.Signer) error {
+	if kid == "" {
+		return errEmptyKeyID
+	}
+	if key == nil {
+		return errUnsupportedKeyType
+	}
+	if _, err := signingMethodForKey(key); err != nil {
+		return err
+	}
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+	ks.private[kid] = key
+	ks.public[kid] = key.Public()
+	if ks.signing == "" {
+		ks.signing = kid
+	}
+	return nil
+}
+
+//AddPublic adds a verification only key to the set (ie: a key fetched from another service's JWKS)
+func (ks *KeySet) AddPublic(kid string, key crypto.PublicKey) error {
+	if kid == "" {
+		return errEmptyKeyID
+	}
+	if _, err := signingMethodForKey(key); err != nil {
+		return err
+	}
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+	ks.public[kid] = key
+	return nil
+}
+
+//Remove a key from the set. Tokens signed with it will not validate anymore
+func (ks *KeySet) Remove(kid string) {
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+	delete(ks.private, kid)
+	delete(ks.public, kid)
+	if ks.signing == kid {
+		ks.signing = ""
+	}
+}
+
+//SetSigningKey selects the private key used to sign new tokens
+func (ks *KeySet) SetSigningKey(kid string) error {
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+	if _, ok := ks.private[kid]; !ok {
+		if _, ok = ks.public[kid]; ok {
+			return errNotSignable
+		}
+		return ErrUnknownKey
+	}
+	ks.signing = kid
+	return nil
+}
+
+//Picker returns a SignerPicker that always returns the current signing key
+func (ks *KeySet) Picker() SignerPicker {
+	return func() (string, crypto.Signer) {
+		ks.mu.RLock()
+		defer ks.mu.RUnlock()
+		return ks.signing, ks.private[ks.signing]
+	}
+}
+
+//KeyFunc returns a jwt.Keyfunc resolving the token's kid header to the matching public key
+func (ks *KeySet) KeyFunc() jwt.Keyfunc {
+	return func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		if kid == "" {
+			return nil, errMissingKey
+		}
+		ks.mu.RLock()
+		defer ks.mu.RUnlock()
+		key, ok := ks.public[kid]
+		if !ok {
+			return nil, ErrUnknownKey
+		}
+		return key, nil
+	}
+}
+
+//JWKS returns the public keys of the set, sorted by key ID
+func (ks *KeySet) JWKS() (*JWKS, error) {
+	ks.mu.RLock()
+	defer ks.mu.RUnlock()
+	kids := make([]string, 0, len(ks.public))
+	for kid := range ks.public {
+		kids = append(kids, kid)
+	}
+	sort.Strings(kids)
+	res := &JWKS{Keys: make([]*JWK, 0, len(kids))}
+	for _, kid := range kids {
+		k, err := NewJWK(kid, ks.public[kid])
+		if err != nil {
+			return nil, err
+		}
+		res.Keys = append(res.Keys, k)
+	}
+	return res, nil
+}
+
+//MarshalJSON encodes the public keys of the set as a JWKS document
+func (ks *KeySet) MarshalJSON() ([]byte, error) {
+	doc, err := ks.JWKS()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(doc)
+}
+
+//ServeHTTP publishes the JWKS document (mount it on JWKSPath)
+func (ks *KeySet) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	serveJWKS(w, r, ks.JWKS)
+}
+
+func serveJWKS(w http.ResponseWriter, r *http.Request, jwks func() (*JWKS, error)) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	doc, err := jwks()
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	b, err := json.Marshal(doc)
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	w.Write(b)
+}
+
+//ParseJWKS returns a verification only KeySet from a JWKS document
+func ParseJWKS(data []byte) (*KeySet, error) {
+	doc := &JWKS{}
+	if err := json.Unmarshal(data, doc); err != nil {
+		return nil, err
+	}
+	ks := NewKeySet()
+	for _, k := range doc.Keys {
+		if k == nil || (k.Use != "" && k.Use != "sig") {
+			continue
+		}
+		pub, err := k.PublicKey()
+		if err != nil {
+			return nil, err
+		}
+		if err = ks.AddPublic(k.Kid, pub); err != nil {
+			return nil, err
+		}
+	}
+	return ks, nil
+}
+
+//NewJWK returns the JWK representation of a public key
+func NewJWK(kid string, key crypto.PublicKey) (*JWK, error) {
+	method, err := signingMethodForKey(key)
+	if err != nil {
+		return nil, err
+	}
+	k := &JWK{Kid: kid, Use: "sig", Alg: method.Alg()}
+	switch pub := key.(type) {
+	case *rsa.PublicKey:
+		k.Kty = "RSA"
+		k.N = b64(pub.N.Bytes())
+		k.E = b64(big.NewInt(int64(pub.E)).Bytes())
+	case *ecdsa.PublicKey:
+		size := (pub.Curve.Params().BitSize + 7) / 8
+		k.Kty = "EC"
+		k.Crv = pub.Curve.Params().Name
+		k.X = b64(padLeft(pub.X.Bytes(), size))
+		k.Y = b64(padLeft(pub.Y.Bytes(), size))
+	case ed25519.PublicKey:
+		k.Kty = "OKP"
+		k.Crv = "Ed25519"
+		k.X = b64(pub)
+	default:
+		return nil, errUnsupportedKeyType
+	}
+	return k, nil
+}
+
+//PublicKey decodes the JWK
+func (k *JWK) PublicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := unb64(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := unb64(k.E)
+		if err != nil {
+			return nil, err
+		}
+		if len(n) == 0 || len(e) == 0 || len(e) > 4 {
+			return nil, errInvalidJWK
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, errUnsupportedKeyType
+		}
+		x, err := unb64(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := unb64(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		pub := &ecdsa.PublicKey{Curve: curve, X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+		if !curve.IsOnCurve(pub.X, pub.Y) {
+			return nil, errInvalidJWK
+		}
+		return pub, nil
+	case "OKP":
+		if k.Crv != "Ed25519" {
+			return nil, errUnsupportedKeyType
+		}
+		x, err := unb64(k.X)
+		if err != nil {
+			return nil, err
+		}
+		if len(x) != ed25519.PublicKeySize {
+			return nil, errInvalidJWK
+		}
+		return ed25519.PublicKey(x), nil
+	default:
+		return nil, errUnsupportedKeyType
+	}
+}
+
+//Thumbprint returns the SHA-256 thumbprint of the JWK (RFC 7638)
+func (k *JWK) Thumbprint() (string, error) {
+	var members map[string]string
+	switch k.Kty {
+	case "RSA":
+		members = map[string]string{"e": k.E, "kty": k.Kty, "n": k.N}
+	case "EC":
+		members = map[string]string{"crv": k.Crv, "kty": k.Kty, "x": k.X, "y": k.Y}
+	case "OKP":
+		members = map[string]string{"crv": k.Crv, "kty": k.Kty, "x": k.X}
+	default:
+		return "", errUnsupportedKeyType
+	}
+	//encoding/json sorts map keys, which is the canonical form of RFC 7638
+	b, err := json.Marshal(members)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(b)
+	return b64(sum[:]), nil
+}
+
+func b64(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func unb64(s string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(s)
+}
+
+func padLeft(b []byte, size int) []byte {
+	if len(b) >= size {
+		return b
+	}
+	res := make([]byte, size)
+	copy(res[size-len(b):], b)
+	return res
+}
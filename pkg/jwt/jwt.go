@@ -11,47 +11,69 @@ import (
 //Handler handles JWT tokens generation and validation
 type Handler interface {
 	Generate(custom *pb.Info, t time.Time, delay time.Duration) (string, error)
-	Validate(token string, dest interface{}) error
+	GenerateWithParams(custom *pb.Info, t time.Time, params *TokenParams) (string, error)
+	Validate(token string) (*AccessToken, error)
+	Validity() time.Duration
+}
+
+//AudienceAcceptor is implemented by handlers validating tokens issued for several audiences
+type AudienceAcceptor interface {
+	AcceptAudiences(audiences ...string)
 }
 
 var (
 	errInvalidClaims            = fmt.Errorf("invalid claims")
 	errFailedToGenerateJwtToken = fmt.Errorf("failed to generate token")
+	errVerifyOnly               = fmt.Errorf("handler can only verify tokens")
 )
 
-//AccessToken holds standard and custom claims
+//AccessToken holds standard and custom claims. Extra is never nil in validated tokens
 type AccessToken struct {
-	Std    *jwt.StandardClaims `json:"claims"`
-	Custom *pb.Info            `json:"account_info"`
+	Std    *Claims      `json:"claims"`
+	Custom *pb.Info     `json:"account_info"`
+	Extra  *ExtraClaims `json:"ext,omitempty"`
 }
 
-//Valid to implement jwt.Claims interface. It calls inner Claims.Valid()
+//Valid to implement jwt.Claims interface. Time based claims are checked by the handler against its clock
 func (at *AccessToken) Valid() error {
-	return at.Std.Valid()
+	if at.Std == nil || at.Custom == nil {
+		return ErrMalformed
+	}
+	return nil
 }
 
 //StdClaimsFunc is a function that validates that a token comes from whitelisted issuer, and has been generated for whitelister audience. subject is the reason of the token (accountID ?)
-type StdClaimsFunc func(claims *jwt.StandardClaims) error
+type StdClaimsFunc func(claims *Claims) error
 
-//CustomClaimsFunc validates custom claims of the token
-type CustomClaimsFunc func(info *pb.Info) error
+//CustomClaimsFunc validates custom claims of the token: the account info and the extra claims
+type CustomClaimsFunc func(info *pb.Info, extra *ExtraClaims) error
 
 //SimpleHandler implements Handler interface
 type SimpleHandler struct {
-	keyPicker  KeyPicker
-	keyFunc    jwt.Keyfunc
+	claimsHandler
+	keyPicker func() (string, interface{})
+	keyFunc   jwt.Keyfunc
+}
+
+//claimsHandler issues and validates the claims of a token, whatever its format
+type claimsHandler struct {
 	stdFunc    StdClaimsFunc
 	customFunc CustomClaimsFunc
+	schema     *ClaimsSchema
+	revoked    RevocationStore
+	clock      Clock
+	leeway     time.Duration
 	validity   time.Duration
 	issuer     string
 	audience   string
+	accepted   Audience
 	subject    string
 }
 
 //KeyPicker is a function that returns a keyID (string) and signing key ([]byte)
 type KeyPicker func() (string, []byte)
 
-//NewSimpleHandler returns a new instance of Handler implementing the JWTHandler interface. issuer,audience and subject will be used when generating jwt.StandardClaims
+//NewSimpleHandler returns a new instance of Handler implementing the JWTHandler interface. issuer,audience and subject will be used when generating Claims. Only tokens issued for audience are accepted, see AcceptAudiences
 func NewSimpleHandler(issuer, audience, subject string, picker KeyPicker, keyFunc jwt.Keyfunc, stdFunc StdClaimsFunc, customFunc CustomClaimsFunc, validity time.Duration) (*SimpleHandler, error) {
 	if picker == nil {
 		return nil, fmt.Errorf("key picker is nil")
@@ -65,44 +87,186 @@ func NewSimpleHandler(issuer, audience, subject string, picker KeyPicker, keyFun
 	if customFunc == nil {
 		return nil, fmt.Errorf("infoValidator is nil")
 	}
+	kp := func() (string, interface{}) {
+		return picker()
+	}
+	return &SimpleHandler{claimsHandler: newClaimsHandler(issuer, audience, subject, stdFunc, customFunc, validity), keyPicker: kp, keyFunc: strictKeyFunc(keyFunc)}, nil
+}
+
+func newClaimsHandler(issuer, audience, subject string, stdFunc StdClaimsFunc, customFunc CustomClaimsFunc, validity time.Duration) claimsHandler {
 	if validity < 0 {
 		validity *= -1
 	}
-	return &SimpleHandler{keyPicker: picker, keyFunc: keyFunc, stdFunc: stdFunc, customFunc: customFunc, validity: validity, issuer: issuer, audience: audience, subject: subject}, nil
+	return claimsHandler{stdFunc: stdFunc, customFunc: customFunc, clock: SystemClock, validity: validity, issuer: issuer, audience: audience, subject: subject}
 }
 
-//Validate a token string
-func (h *SimpleHandler) Validate(token string, dest interface{}) error {
-	c, ok := dest.(*AccessToken)
-	if !ok {
-		return fmt.Errorf("destination must be of type *AccessToken")
+//NewAsymmetricHandler returns a Handler signing tokens with RSA (RS256), ECDSA (ES256/ES384/ES512) or Ed25519 (EdDSA) private keys.
+//keyFunc must return the public key matching the token's kid. picker can be nil for services that only verify tokens
+func NewAsymmetricHandler(issuer, audience, subject string, picker SignerPicker, keyFunc jwt.Keyfunc, stdFunc StdClaimsFunc, customFunc CustomClaimsFunc, validity time.Duration) (*SimpleHandler, error) {
+	if keyFunc == nil {
+		return nil, fmt.Errorf("keyFunc is nil")
+	}
+	if stdFunc == nil {
+		return nil, fmt.Errorf("claimsValidator is nil")
+	}
+	if customFunc == nil {
+		return nil, fmt.Errorf("infoValidator is nil")
+	}
+	var kp func() (string, interface{})
+	if picker != nil {
+		kp = func() (string, interface{}) {
+			return picker()
+		}
 	}
-	_, err := jwt.ParseWithClaims(token, c, h.keyFunc)
+	return &SimpleHandler{claimsHandler: newClaimsHandler(issuer, audience, subject, stdFunc, customFunc, validity), keyPicker: kp, keyFunc: strictKeyFunc(keyFunc)}, nil
+}
+
+//Validate a token string and return its claims. Errors are one of the Err* values of this package, or the errors returned by stdFunc and customFunc
+func (h *SimpleHandler) Validate(token string) (*AccessToken, error) {
+	c := &AccessToken{}
+	parser := &jwt.Parser{SkipClaimsValidation: true}
+	_, err := parser.ParseWithClaims(token, c, h.keyFunc)
 	if err != nil {
+		return nil, validationError(err)
+	}
+	if err = h.validateClaims(c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+//validateClaims checks the claims of a token whose signature was verified
+func (h *claimsHandler) validateClaims(c *AccessToken) error {
+	var err error
+	if c.Std == nil || c.Custom == nil {
+		return ErrMalformed
+	}
+	if c.Extra == nil {
+		c.Extra = &ExtraClaims{}
+	}
+	if c.Extra.App, err = h.schema.decode(c.Extra.App); err != nil {
+		return ErrMalformed
+	}
+	if err = h.checkTimes(c.Std); err != nil {
 		return err
 	}
+	if c.Std.Issuer != h.issuer {
+		return ErrWrongIssuer
+	}
+	if !h.acceptsAudience(c.Std.Audience) {
+		return ErrWrongAudience
+	}
+	if c.Std.Subject != h.subject {
+		return ErrWrongSubject
+	}
 	if err = h.stdFunc(c.Std); err != nil {
 		return err
 	}
-	return h.customFunc(c.Custom)
+	if err = h.customFunc(c.Custom, c.Extra); err != nil {
+		return err
+	}
+	return h.checkRevoked(c)
+}
+
+//AcceptAudiences adds audiences to the ones accepted by Validate: a token is accepted if it was issued for at least one of them
+func (h *claimsHandler) AcceptAudiences(audiences ...string) {
+	for _, aud := range audiences {
+		if aud != "" && !h.accepted.Contains(aud) {
+			h.accepted = append(h.accepted, aud)
+		}
+	}
+}
+
+func (h *claimsHandler) acceptsAudience(aud Audience) bool {
+	for _, a := range aud {
+		if a == h.audience || h.accepted.Contains(a) {
+			return true
+		}
+	}
+	return false
+}
+
+//checkTimes validates exp, nbf and iat claims against the handler's clock, tolerating a clock skew of leeway
+func (h *claimsHandler) checkTimes(std *Claims) error {
+	t := h.clock.Now()
+	now := t.Unix()
+	leeway := int64(h.leeway / time.Second)
+	if std.ExpiresAt != 0 && now > std.ExpiresAt+leeway {
+		return ErrExpired
+	}
+	if std.NotBefore != 0 && now+leeway < std.NotBefore {
+		return ErrNotValidYet
+	}
+	if std.IssuedAt != 0 && t.Add(h.leeway).Before(std.IssuedAtTime()) {
+		return ErrNotValidYet
+	}
+	return nil
+}
+
+//SetClock replaces the wall clock used to validate tokens
+func (h *claimsHandler) SetClock(clock Clock) {
+	if clock != nil {
+		h.clock = clock
+	}
+}
+
+//SetLeeway sets the clock skew tolerated on exp, nbf and iat claims
+func (h *claimsHandler) SetLeeway(leeway time.Duration) {
+	if leeway < 0 {
+		leeway *= -1
+	}
+	h.leeway = leeway
+}
+
+//SetClaimsSchema registers the application specific claims tokens can hold. Without a schema, tokens with application claims are rejected
+func (h *claimsHandler) SetClaimsSchema(schema *ClaimsSchema) {
+	h.schema = schema
+}
+
+//SetRevocationStore makes Validate reject revoked tokens
+func (h *claimsHandler) SetRevocationStore(store RevocationStore) {
+	h.revoked = store
+}
+
+func (h *claimsHandler) checkRevoked(c *AccessToken) error {
+	if h.revoked == nil {
+		return nil
+	}
+	revoked, err := h.revoked.IsRevoked(c.Std.Id, c.Custom.Uid, c.Std.IssuedAtTime())
+	if err != nil {
+		return err
+	}
+	if revoked {
+		return ErrRevoked
+	}
+	return nil
+}
+
+//Validity of generated tokens
+func (h *claimsHandler) Validity() time.Duration {
+	return h.validity
 }
 
 //Generate returns a JWT token string: delay is used in not before, t is used for issued at and validity is read from inner value
 func (h *SimpleHandler) Generate(custom *pb.Info, t time.Time, delay time.Duration) (string, error) {
-	if custom == nil {
-		return "", errInvalidClaims
+	return h.GenerateWithParams(custom, t, &TokenParams{Delay: delay})
+}
+
+//GenerateWithParams returns a JWT token string with the token ID, audiences, delay and extra claims of params
+func (h *SimpleHandler) GenerateWithParams(custom *pb.Info, t time.Time, params *TokenParams) (string, error) {
+	if h.keyPicker == nil {
+		return "", errVerifyOnly
 	}
-	if delay < 0 {
-		delay *= -1
+	at, err := h.newClaims(custom, t, params)
+	if err != nil {
+		return "", err
 	}
-	t.UTC()
-	std := &jwt.StandardClaims{Issuer: h.issuer, Audience: h.audience, Subject: h.subject}
-	std.IssuedAt = t.Unix()
-	std.ExpiresAt = t.Add(h.validity).Unix()
-	std.NotBefore = t.Add(delay).Unix()
-	at := &AccessToken{Std: std, Custom: custom}
-	jwtoken := jwt.NewWithClaims(jwt.SigningMethodHS256, at)
 	keyID, signingKey := h.keyPicker()
+	method, err := signingMethodForKey(signingKey)
+	if err != nil {
+		return "", errFailedToGenerateJwtToken
+	}
+	jwtoken := jwt.NewWithClaims(method, at)
 	jwtoken.Header["kid"] = keyID
 	tokenstr, err := jwtoken.SignedString(signingKey)
 	if err != nil {
@@ -110,3 +274,39 @@ func (h *SimpleHandler) Generate(custom *pb.Info, t time.Time, delay time.Durati
 	}
 	return tokenstr, nil
 }
+
+//newClaims returns the claims of a token issued at t for params
+func (h *claimsHandler) newClaims(custom *pb.Info, t time.Time, params *TokenParams) (*AccessToken, error) {
+	if custom == nil {
+		return nil, errInvalidClaims
+	}
+	if params == nil {
+		params = &TokenParams{}
+	}
+	delay := params.Delay
+	if delay < 0 {
+		delay *= -1
+	}
+	id := params.ID
+	if id == "" {
+		id = NewTokenID()
+	}
+	aud := Audience{h.audience}
+	if len(params.Audiences) > 0 {
+		aud = Audience(params.Audiences)
+	}
+	t.UTC()
+	std := &Claims{Id: id, Issuer: h.issuer, Audience: aud, Subject: h.subject}
+	std.IssuedAt = numericDate(t)
+	std.ExpiresAt = t.Add(h.validity).Unix()
+	if !params.NotAfter.IsZero() && params.NotAfter.Unix() < std.ExpiresAt {
+		std.ExpiresAt = params.NotAfter.Unix()
+	}
+	std.NotBefore = t.Add(delay).Unix()
+	if params.Extra != nil {
+		if _, err := h.schema.decode(params.Extra.App); err != nil {
+			return nil, errInvalidClaims
+		}
+	}
+	return &AccessToken{Std: std, Custom: custom, Extra: params.Extra}, nil
+}
@@ -1,7 +1,778 @@
 package jwt
 
-import "testing"
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	pb "github.com/klahssen/authn/proto-gen/accounts/apiv1"
+	"golang.org/x/crypto/ed25519"
+)
 
 func TestSimpleHandler(t *testing.T) {
+	secret := []byte("abcdef")
+	picker := func() (string, []byte) { return "k1", secret }
+	keyFunc := func(token *jwt.Token) (interface{}, error) {
+		if token.Header["kid"] != "k1" {
+			return nil, ErrUnknownKey
+		}
+		return secret, nil
+	}
+	newHandler := func(issuer, audience, subject string) *SimpleHandler {
+		h, err := NewSimpleHandler(issuer, audience, subject, picker, keyFunc, noopStd, noopCustom, time.Minute)
+		if err != nil {
+			t.Fatalf("failed to create handler: %v", err)
+		}
+		return h
+	}
+	h := newHandler("authn", "authn", "access")
+	store := NewMemRevocationStore()
+	h.SetRevocationStore(store)
+	info := &pb.Info{Type: "user", Uid: "uid"}
+	generate := func(h *SimpleHandler, t time.Time, delay time.Duration) string {
+		token, err := h.Generate(info, t, delay)
+		if err != nil {
+			log.Fatalf("failed to generate token: %v", err)
+		}
+		return token
+	}
+	now := time.Now()
+	valid := generate(h, now, 0)
+	revoked := generate(h, now, 0)
+	parsed, _, _ := new(jwt.Parser).ParseUnverified(revoked, &AccessToken{})
+	store.Revoke(parsed.Claims.(*AccessToken).Std.Id, now.Add(time.Minute))
+	unknownKid, _ := NewSimpleHandler("authn", "authn", "access", func() (string, []byte) { return "k2", secret }, keyFunc, noopStd, noopCustom, time.Minute)
+	otherSecret, _ := NewSimpleHandler("authn", "authn", "access", func() (string, []byte) { return "k1", []byte("ghijkl") }, keyFunc, noopStd, noopCustom, time.Minute)
+	tests := []struct {
+		token string
+		err   error
+	}{
+		{valid, nil},
+		{"abc", ErrMalformed},
+		{generate(h, now.Add(-time.Hour), 0), ErrExpired},
+		{generate(h, now, time.Hour), ErrNotValidYet},
+		{generate(otherSecret, now, 0), ErrBadSignature},
+		{generate(unknownKid, now, 0), ErrUnknownKey},
+		{generate(newHandler("authn", "billing", "access"), now, 0), ErrWrongAudience},
+		{generate(newHandler("other", "authn", "access"), now, 0), ErrWrongIssuer},
+		{generate(newHandler("authn", "authn", "refresh"), now, 0), ErrWrongSubject},
+		{revoked, ErrRevoked},
+	}
+	for ind, test := range tests {
+		at, err := h.Validate(test.token)
+		if err != test.err {
+			t.Errorf("test %d: expected %v received %v", ind, test.err, err)
+			continue
+		}
+		if err == nil && at.Custom.Uid != info.Uid {
+			t.Errorf("test %d: expected uid %s received %s", ind, info.Uid, at.Custom.Uid)
+		}
+	}
+	//revoking an account does not revoke the tokens issued later in the same second
+	second := now.Truncate(time.Second).Add(-time.Second)
+	other := &pb.Info{Type: "user", Uid: "other"}
+	before, _ := h.Generate(other, second.Add(time.Millisecond*100), 0)
+	store.RevokeAccount(other.Uid, second.Add(time.Millisecond*200), now.Add(time.Minute))
+	after, _ := h.Generate(other, second.Add(time.Millisecond*300), 0)
+	if _, err := h.Validate(before); err != ErrRevoked {
+		t.Errorf("expected %v received %v", ErrRevoked, err)
+	}
+	if _, err := h.Validate(after); err != nil {
+		t.Errorf("token issued after the account revocation: %v", err)
+	}
+}
+
+func noopStd(claims *Claims) error                       { return nil }
+func noopCustom(info *pb.Info, extra *ExtraClaims) error { return nil }
+
+func TestAsymmetricHandler(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate rsa key: %v", err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate ecdsa key: %v", err)
+	}
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate ed25519 key: %v", err)
+	}
+	tests := []struct {
+		priv crypto.Signer
+		pub  interface{}
+		alg  string
+	}{
+		{rsaKey, &rsaKey.PublicKey, "RS256"},
+		{ecKey, &ecKey.PublicKey, "ES256"},
+		{edKey, edKey.Public(), "EdDSA"},
+	}
+	info := &pb.Info{Type: "user", Uid: "uid", Roles: []string{"user"}}
+	for ind, test := range tests {
+		priv := test.priv
+		pub := test.pub
+		signer, err := NewAsymmetricHandler("authn", "authn", "access", func() (string, crypto.Signer) { return "k1", priv }, func(*jwt.Token) (interface{}, error) { return pub, nil }, noopStd, noopCustom, time.Minute)
+		if err != nil {
+			t.Fatalf("test %d: failed to create handler: %v", ind, err)
+		}
+		verifier, err := NewAsymmetricHandler("authn", "authn", "access", nil, func(*jwt.Token) (interface{}, error) { return pub, nil }, noopStd, noopCustom, time.Minute)
+		if err != nil {
+			t.Fatalf("test %d: failed to create verifier: %v", ind, err)
+		}
+		if _, err = verifier.Generate(info, time.Now(), 0); err != errVerifyOnly {
+			t.Errorf("test %d: expected %v received %v", ind, errVerifyOnly, err)
+		}
+		token, err := signer.Generate(info, time.Now(), 0)
+		if err != nil {
+			t.Errorf("test %d: failed to generate token: %v", ind, err)
+			continue
+		}
+		at, err := verifier.Validate(token)
+		if err != nil {
+			t.Errorf("test %d: expected valid token received %v", ind, err)
+			continue
+		}
+		parsed, _, _ := new(jwt.Parser).ParseUnverified(token, &AccessToken{})
+		if parsed.Method.Alg() != test.alg {
+			t.Errorf("test %d: expected alg %s received %s", ind, test.alg, parsed.Method.Alg())
+		}
+		if at.Custom.Uid != info.Uid {
+			t.Errorf("test %d: expected uid %s received %s", ind, info.Uid, at.Custom.Uid)
+		}
+	}
+}
+
+func TestRejectsAlgMismatch(t *testing.T) {
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate ecdsa key: %v", err)
+	}
+	secret := []byte("abcdef")
+	hmac, err := NewSimpleHandler("authn", "authn", "access", func() (string, []byte) { return "k1", secret }, func(*jwt.Token) (interface{}, error) { return secret, nil }, noopStd, noopCustom, time.Minute)
+	if err != nil {
+		t.Fatalf("failed to create handler: %v", err)
+	}
+	verifier, err := NewAsymmetricHandler("authn", "authn", "access", nil, func(*jwt.Token) (interface{}, error) { return &ecKey.PublicKey, nil }, noopStd, noopCustom, time.Minute)
+	if err != nil {
+		t.Fatalf("failed to create verifier: %v", err)
+	}
+	token, err := hmac.Generate(&pb.Info{Uid: "uid"}, time.Now(), 0)
+	if err != nil {
+		t.Fatalf("failed to generate token: %v", err)
+	}
+	if _, err = verifier.Validate(token); err != ErrBadSignature {
+		t.Errorf("expected HS256 token to be rejected by an ES256 verifier with %v received %v", ErrBadSignature, err)
+	}
+}
+
+func TestKeySet(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate rsa key: %v", err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate ecdsa key: %v", err)
+	}
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate ed25519 key: %v", err)
+	}
+	ks := NewKeySet()
+	for kid, key := range map[string]crypto.Signer{"rsa": rsaKey, "ec": ecKey, "ed": edKey} {
+		if err = ks.Add(kid, key); err != nil {
+			t.Fatalf("failed to add key %s: %v", kid, err)
+		}
+	}
+	rec := httptest.NewRecorder()
+	ks.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, JWKSPath, nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d received %d", http.StatusOK, rec.Code)
+	}
+	remote, err := ParseJWKS(rec.Body.Bytes())
+	if err != nil {
+		t.Fatalf("failed to parse jwks: %v", err)
+	}
+	if err = remote.SetSigningKey("rsa"); err != errNotSignable {
+		t.Errorf("expected %v received %v", errNotSignable, err)
+	}
+	info := &pb.Info{Type: "user", Uid: "uid"}
+	for ind, kid := range []string{"rsa", "ec", "ed"} {
+		if err = ks.SetSigningKey(kid); err != nil {
+			t.Fatalf("test %d: failed to set signing key: %v", ind, err)
+		}
+		signer, err := NewAsymmetricHandler("authn", "authn", "access", ks.Picker(), ks.KeyFunc(), noopStd, noopCustom, time.Minute)
+		if err != nil {
+			t.Fatalf("test %d: failed to create handler: %v", ind, err)
+		}
+		verifier, err := NewAsymmetricHandler("authn", "authn", "access", nil, remote.KeyFunc(), noopStd, noopCustom, time.Minute)
+		if err != nil {
+			t.Fatalf("test %d: failed to create verifier: %v", ind, err)
+		}
+		token, err := signer.Generate(info, time.Now(), 0)
+		if err != nil {
+			t.Errorf("test %d: failed to generate token: %v", ind, err)
+			continue
+		}
+		if _, err = verifier.Validate(token); err != nil {
+			t.Errorf("test %d: expected token signed with %s to validate received %v", ind, kid, err)
+		}
+		remote.Remove(kid)
+		if _, err = verifier.Validate(token); err != ErrUnknownKey {
+			t.Errorf("test %d: expected token signed with removed key %s to be rejected with %v received %v", ind, kid, ErrUnknownKey, err)
+		}
+	}
+}
+
+func TestKeyRing(t *testing.T) {
+	ttl := time.Hour
+	kr, err := NewKeyRing(ttl)
+	if err != nil {
+		t.Fatalf("failed to create key ring: %v", err)
+	}
+	clock := NewFakeClock(time.Unix(1500000000, 0))
+	kr.SetClock(clock)
+	now := clock.Now()
+	keys := map[string]ed25519.PrivateKey{}
+	for _, kid := range []string{"k1", "k2", "k3"} {
+		_, keys[kid], err = ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			t.Fatalf("failed to generate ed25519 key: %v", err)
+		}
+	}
+	if err = kr.Stage("k1", keys["k1"], time.Time{}); err != nil {
+		t.Fatalf("failed to stage k1: %v", err)
+	}
+	if err = kr.Stage("k1", keys["k1"], time.Time{}); err != errKeyExists {
+		t.Errorf("expected %v received %v", errKeyExists, err)
+	}
+	//k2 activates automatically in 10 minutes, k3 is promoted manually
+	if err = kr.Stage("k2", keys["k2"], now.Add(10*time.Minute)); err != nil {
+		t.Fatalf("failed to stage k2: %v", err)
+	}
+	if err = kr.Stage("k3", keys["k3"], now.Add(24*time.Hour)); err != nil {
+		t.Fatalf("failed to stage k3: %v", err)
+	}
+	pick := kr.Picker()
+	verify := kr.KeyFunc()
+	signedBy := func(kid string) *jwt.Token {
+		return &jwt.Token{Header: map[string]interface{}{"kid": kid}}
+	}
+	tests := []struct {
+		elapsed  time.Duration
+		promote  string
+		retire   string
+		signer   string
+		verifies map[string]bool
+	}{
+		{0, "", "", "k1", map[string]bool{"k1": true, "k2": true, "k3": true}},
+		{10 * time.Minute, "", "", "k2", map[string]bool{"k1": true, "k2": true, "k3": true}},
+		{20 * time.Minute, "k3", "", "k3", map[string]bool{"k1": true, "k2": true, "k3": true}},
+		{70 * time.Minute, "", "", "k3", map[string]bool{"k1": false, "k2": true, "k3": true}},
+		{80 * time.Minute, "", "", "k3", map[string]bool{"k1": false, "k2": false, "k3": true}},
+		//retiring the newest key does not bring back the keys it retired
+		{90 * time.Minute, "", "k3", "", map[string]bool{"k1": false, "k2": false, "k3": true}},
+		{140 * time.Minute, "", "", "", map[string]bool{"k1": false, "k2": false, "k3": true}},
+	}
+	start := now
+	for ind, test := range tests {
+		clock.Set(start.Add(test.elapsed))
+		if test.promote != "" {
+			if err = kr.Promote(test.promote); err != nil {
+				t.Fatalf("test %d: failed to promote %s: %v", ind, test.promote, err)
+			}
+		}
+		if test.retire != "" {
+			if err = kr.Retire(test.retire); err != nil {
+				t.Fatalf("test %d: failed to retire %s: %v", ind, test.retire, err)
+			}
+		}
+		kid, _ := pick()
+		if kid != test.signer {
+			t.Errorf("test %d: expected signer %s received %s", ind, test.signer, kid)
+		}
+		for kid, ok := range test.verifies {
+			_, err = verify(signedBy(kid))
+			if (err == nil) != ok {
+				t.Errorf("test %d: expected %s to verify: %v received err %v", ind, kid, ok, err)
+			}
+		}
+	}
+	kr.Purge()
+	if l := len(kr.Keys()); l != 1 {
+		t.Errorf("expected 1 key after purge received %d", l)
+	}
+}
+
+func TestLeeway(t *testing.T) {
+	secret := []byte("abcdef")
+	clock := NewFakeClock(time.Unix(1500000000, 0))
+	h, err := NewSimpleHandler("authn", "authn", "access", func() (string, []byte) { return "k1", secret }, func(*jwt.Token) (interface{}, error) { return secret, nil }, noopStd, noopCustom, time.Minute)
+	if err != nil {
+		t.Fatalf("failed to create handler: %v", err)
+	}
+	h.SetClock(clock)
+	issued, err := h.Generate(&pb.Info{Uid: "uid"}, clock.Now(), 0)
+	if err != nil {
+		t.Fatalf("failed to generate token: %v", err)
+	}
+	//issued by a host whose clock is 5s ahead
+	ahead, err := h.Generate(&pb.Info{Uid: "uid"}, clock.Now().Add(5*time.Second), 0)
+	if err != nil {
+		t.Fatalf("failed to generate token: %v", err)
+	}
+	tests := []struct {
+		token   string
+		elapsed time.Duration
+		leeway  time.Duration
+		err     error
+	}{
+		{issued, 0, 0, nil},
+		{ahead, 0, 0, ErrNotValidYet},
+		{ahead, 0, 10 * time.Second, nil},
+		{issued, time.Minute, 0, nil},
+		{issued, time.Minute + 5*time.Second, 0, ErrExpired},
+		{issued, time.Minute + 5*time.Second, 10 * time.Second, nil},
+		{issued, time.Minute + 15*time.Second, 10 * time.Second, ErrExpired},
+	}
+	start := clock.Now()
+	for ind, test := range tests {
+		clock.Set(start.Add(test.elapsed))
+		h.SetLeeway(test.leeway)
+		if _, err = h.Validate(test.token); err != test.err {
+			t.Errorf("test %d: expected %v received %v", ind, test.err, err)
+		}
+	}
+}
+
+func TestAudiences(t *testing.T) {
+	secret := []byte("abcdef")
+	kp := func() (string, []byte) { return "k1", secret }
+	kf := func(*jwt.Token) (interface{}, error) { return secret, nil }
+	issuer, err := NewSimpleHandler("authn", "authn", "access", kp, kf, noopStd, noopCustom, time.Minute)
+	if err != nil {
+		t.Fatalf("failed to create handler: %v", err)
+	}
+	//billing only accepts tokens issued for itself
+	billing, err := NewSimpleHandler("authn", "billing", "access", kp, kf, noopStd, noopCustom, time.Minute)
+	if err != nil {
+		t.Fatalf("failed to create handler: %v", err)
+	}
+	issuer.AcceptAudiences("billing", "orders")
+	tests := []struct {
+		audiences []string
+		h         *SimpleHandler
+		err       error
+	}{
+		{nil, issuer, nil},
+		{nil, billing, ErrWrongAudience},
+		{[]string{"billing"}, issuer, nil},
+		{[]string{"billing"}, billing, nil},
+		{[]string{"orders"}, billing, ErrWrongAudience},
+		{[]string{"orders", "billing"}, billing, nil},
+		{[]string{"shipping"}, issuer, ErrWrongAudience},
+	}
+	for ind, test := range tests {
+		token, err := issuer.GenerateWithParams(&pb.Info{Uid: "uid"}, time.Now(), &TokenParams{Audiences: test.audiences})
+		if err != nil {
+			t.Fatalf("test %d: failed to generate token: %v", ind, err)
+		}
+		claims, err := test.h.Validate(token)
+		if err != test.err {
+			t.Errorf("test %d: expected %v received %v", ind, test.err, err)
+		}
+		if err != nil {
+			continue
+		}
+		for _, aud := range test.audiences {
+			if !claims.Std.Audience.Contains(aud) {
+				t.Errorf("test %d: missing audience '%s' in %v", ind, aud, claims.Std.Audience)
+			}
+		}
+	}
+}
+
+type quota struct {
+	Requests int `json:"requests"`
+}
+
+func TestExtraClaims(t *testing.T) {
+	secret := []byte("abcdef")
+	kp := func() (string, []byte) { return "k1", secret }
+	kf := func(*jwt.Token) (interface{}, error) { return secret, nil }
+	errNoMFA := fmt.Errorf("mfa required")
+	requireMFA := func(info *pb.Info, extra *ExtraClaims) error {
+		for _, m := range extra.AuthMethods {
+			if m == AuthMethodMFA {
+				return nil
+			}
+		}
+		return errNoMFA
+	}
+	schema := NewClaimsSchema()
+	if err := schema.Register("plan", ""); err != nil {
+		t.Fatalf("failed to register claim: %v", err)
+	}
+	if err := schema.Register("quota", quota{}); err != nil {
+		t.Fatalf("failed to register claim: %v", err)
+	}
+	if err := schema.Register("plan", ""); err != errClaimRegistered {
+		t.Errorf("expected %v received %v", errClaimRegistered, err)
+	}
+	h, err := NewSimpleHandler("authn", "authn", "access", kp, kf, noopStd, requireMFA, time.Minute)
+	if err != nil {
+		t.Fatalf("failed to create handler: %v", err)
+	}
+	h.SetClaimsSchema(schema)
+	//default handler: no application claims registered
+	def, err := NewSimpleHandler("authn", "authn", "access", kp, kf, noopStd, noopCustom, time.Minute)
+	if err != nil {
+		t.Fatalf("failed to create handler: %v", err)
+	}
+	extra := &ExtraClaims{
+		Tenant:      "acme",
+		SessionID:   "s1",
+		AuthMethods: []string{AuthMethodPassword, AuthMethodMFA},
+		Scopes:      Scopes{"accounts:read", "accounts:write"},
+		App:         map[string]interface{}{"plan": "pro", "quota": quota{Requests: 10}},
+	}
+	token, err := h.GenerateWithParams(&pb.Info{Uid: "uid"}, time.Now(), &TokenParams{Extra: extra})
+	if err != nil {
+		t.Fatalf("failed to generate token: %v", err)
+	}
+	claims, err := h.Validate(token)
+	if err != nil {
+		t.Fatalf("failed to validate token: %v", err)
+	}
+	if !reflect.DeepEqual(extra, claims.Extra) {
+		t.Errorf("expected %+v received %+v", extra, claims.Extra)
+	}
+	if _, err = def.Validate(token); err != ErrMalformed {
+		t.Errorf("expected %v received %v", ErrMalformed, err)
+	}
+	if _, err = h.GenerateWithParams(&pb.Info{Uid: "uid"}, time.Now(), &TokenParams{Extra: &ExtraClaims{App: map[string]interface{}{"unknown": 1}}}); err != errInvalidClaims {
+		t.Errorf("expected %v received %v", errInvalidClaims, err)
+	}
+	pwdOnly, err := h.GenerateWithParams(&pb.Info{Uid: "uid"}, time.Now(), &TokenParams{Extra: &ExtraClaims{AuthMethods: []string{AuthMethodPassword}}})
+	if err != nil {
+		t.Fatalf("failed to generate token: %v", err)
+	}
+	if _, err = h.Validate(pwdOnly); err != errNoMFA {
+		t.Errorf("expected %v received %v", errNoMFA, err)
+	}
+	claims, err = def.Validate(pwdOnly)
+	if err != nil {
+		t.Fatalf("failed to validate token: %v", err)
+	}
+	if claims.Extra.Tenant != "" || claims.Extra.Scopes != nil {
+		t.Errorf("unexpected extra claims %+v", claims.Extra)
+	}
+}
+
+func TestPasetoVectors(t *testing.T) {
+	//test vectors 4-S-1 and 4-E-1 of the PASETO specification
+	sk, _ := hex.DecodeString("b4cbfb43df4ce210727d953e4a713307fa19bb7d9f85041438d9e11b942a37741eb9dbbbbc047c03fd70604e0071f0987e16b28b757225c11f00415d0e20b1a2")
+	signed := "v4.public.eyJkYXRhIjoidGhpcyBpcyBhIHNpZ25lZCBtZXNzYWdlIiwiZXhwIjoiMjAyMi0wMS0wMVQwMDowMDowMCswMDowMCJ9bg_XBBzds8lTZShVlwwKSgeKpLT3yukTw6JUz3W4h_ExsQV-P0V54zemZDcAxFaSeef1QlXEFtkqxT1ciiQEDA"
+	token, err := pasetoSign(ed25519.PrivateKey(sk), []byte(`{"data":"this is a signed message","exp":"2022-01-01T00:00:00+00:00"}`), nil)
+	if err != nil || token != signed {
+		t.Errorf("expected %s received %s (%v)", signed, token, err)
+	}
+	key, _ := hex.DecodeString("707172737475767778797a7b7c7d7e7f808182838485868788898a8b8c8d8e8f")
+	encrypted := "v4.local.AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAQAr68PS4AXe7If_ZgesdkUMvSwscFlAl1pk5HC0e8kApeaqMfGo_7OpBnwJOAbY9V7WU6abu74MmcUE8YWAiaArVI8XJ5hOb_4v9RmDkneN0S92dx0OW4pgy7omxgf3S8c3LlQg"
+	token, err = pasetoEncrypt(key, make([]byte, pasetoNonceSize), []byte(`{"data":"this is a secret message","exp":"2022-01-01T00:00:00+00:00"}`), nil)
+	if err != nil || token != encrypted {
+		t.Errorf("expected %s received %s (%v)", encrypted, token, err)
+	}
+}
+
+func TestPasetoHandler(t *testing.T) {
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate ed25519 key: %v", err)
+	}
+	ks := NewKeySet()
+	if err = ks.Add("ed", edKey); err != nil {
+		t.Fatalf("failed to add key: %v", err)
+	}
+	if err = ks.SetSigningKey("ed"); err != nil {
+		t.Fatalf("failed to set signing key: %v", err)
+	}
+	secret := []byte("0123456789abcdef0123456789abcdef")
+	kp := func() (string, []byte) { return "k1", secret }
+	kf := func(token *jwt.Token) (interface{}, error) {
+		if token.Header["kid"] != "k1" {
+			return nil, ErrUnknownKey
+		}
+		return secret, nil
+	}
+	public, err := NewPasetoPublicHandler("authn", "authn", "access", ks.Picker(), ks.KeyFunc(), noopStd, noopCustom, time.Minute)
+	if err != nil {
+		t.Fatalf("failed to create handler: %v", err)
+	}
+	local, err := NewPasetoLocalHandler("authn", "authn", "access", kp, kf, noopStd, noopCustom, time.Minute)
+	if err != nil {
+		t.Fatalf("failed to create handler: %v", err)
+	}
+	other, err := NewPasetoLocalHandler("authn", "authn", "access", func() (string, []byte) { return "k2", secret }, kf, noopStd, noopCustom, time.Minute)
+	if err != nil {
+		t.Fatalf("failed to create handler: %v", err)
+	}
+	simple, err := NewSimpleHandler("authn", "authn", "access", kp, kf, noopStd, noopCustom, time.Minute)
+	if err != nil {
+		t.Fatalf("failed to create handler: %v", err)
+	}
+	info := &pb.Info{Type: "user", Uid: "uid", Roles: []string{"admin"}}
+	gen := func(h Handler) string {
+		token, err := h.Generate(info, time.Now(), 0)
+		if err != nil {
+			t.Fatalf("failed to generate token: %v", err)
+		}
+		return token
+	}
+	publicToken, localToken := gen(public), gen(local)
+	//tamper changes a character of the token body
+	tamper := func(token string) string {
+		i := strings.LastIndex(token, ".") - 20
+		c := "A"
+		if token[i] == 'A' {
+			c = "B"
+		}
+		return token[:i] + c + token[i+1:]
+	}
+	tests := []struct {
+		h     Handler
+		token string
+		err   error
+	}{
+		{public, publicToken, nil},
+		{local, localToken, nil},
+		{public, localToken, ErrMalformed},
+		{local, publicToken, ErrMalformed},
+		{local, gen(simple), ErrMalformed},
+		{local, gen(other), ErrUnknownKey},
+		{public, tamper(publicToken), ErrBadSignature},
+		{local, tamper(localToken), ErrBadSignature},
+	}
+	for ind, test := range tests {
+		claims, err := test.h.Validate(test.token)
+		if err != test.err {
+			t.Errorf("test %d: expected %v received %v", ind, test.err, err)
+		}
+		if err == nil && !reflect.DeepEqual(info, claims.Custom) {
+			t.Errorf("test %d: expected %+v received %+v", ind, info, claims.Custom)
+		}
+	}
+	//the registered claims are at the top level of the payload, with ISO 8601 times
+	iat := time.Date(2020, 1, 2, 3, 4, 5, 6000, time.UTC)
+	token, err := public.Generate(info, iat, 0)
+	if err != nil {
+		t.Fatalf("failed to generate token: %v", err)
+	}
+	body, err := base64.RawURLEncoding.DecodeString(strings.Split(token[len(PasetoPublic):], ".")[0])
+	if err != nil {
+		t.Fatalf("failed to decode token: %v", err)
+	}
+	payload := map[string]interface{}{}
+	if err = json.Unmarshal(body[:len(body)-ed25519.SignatureSize], &payload); err != nil {
+		t.Fatalf("failed to decode payload: %v", err)
+	}
+	for claim, expected := range map[string]string{"iss": "authn", "sub": "access", "aud": "authn", "iat": "2020-01-02T03:04:05.000006Z", "nbf": "2020-01-02T03:04:05Z", "exp": "2020-01-02T03:05:05Z"} {
+		if payload[claim] != expected {
+			t.Errorf("%s: expected %v received %v", claim, expected, payload[claim])
+		}
+	}
+	if id, _ := payload["jti"].(string); id == "" {
+		t.Errorf("jti: expected a token ID")
+	}
+	if _, ok := payload["claims"]; ok {
+		t.Errorf("expected no nested claims")
+	}
+	//a verify-only handler checks tokens of the signer
+	verifier, err := NewPasetoPublicHandler("authn", "authn", "access", nil, ks.KeyFunc(), noopStd, noopCustom, time.Minute)
+	if err != nil {
+		t.Fatalf("failed to create handler: %v", err)
+	}
+	if _, err = verifier.Validate(publicToken); err != nil {
+		t.Errorf("expected nil received %v", err)
+	}
+	if _, err = verifier.Generate(info, time.Now(), 0); err != errVerifyOnly {
+		t.Errorf("expected %v received %v", errVerifyOnly, err)
+	}
+}
+
+func TestJWEHandler(t *testing.T) {
+	secret := []byte("abcdef")
+	kp := func() (string, []byte) { return "k1", secret }
+	kf := func(*jwt.Token) (interface{}, error) { return secret, nil }
+	inner, err := NewSimpleHandler("authn", "authn", "refresh", kp, kf, noopStd, noopCustom, time.Minute)
+	if err != nil {
+		t.Fatalf("failed to create handler: %v", err)
+	}
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate rsa key: %v", err)
+	}
+	keys := NewEncryptionKeys()
+	if err = keys.Add("short", []byte("abcdef")); err != errInvalidEncryptKey {
+		t.Errorf("expected %v received %v", errInvalidEncryptKey, err)
+	}
+	if err = keys.Add("dir", []byte("0123456789abcdef0123456789abcdef")); err != nil {
+		t.Fatalf("failed to add key: %v", err)
+	}
+	if err = keys.Add("rsa", rsaKey); err != nil {
+		t.Fatalf("failed to add key: %v", err)
+	}
+	h, err := NewJWEHandler(inner, keys)
+	if err != nil {
+		t.Fatalf("failed to create handler: %v", err)
+	}
+	info := &pb.Info{Type: "user", Uid: "secret-uid", Roles: []string{"admin"}}
+	tokens := map[string]string{}
+	for _, kid := range []string{"dir", "rsa"} {
+		if err = keys.SetCurrent(kid); err != nil {
+			t.Fatalf("failed to set current key: %v", err)
+		}
+		token, err := h.Generate(info, time.Now(), 0)
+		if err != nil {
+			t.Fatalf("failed to generate token: %v", err)
+		}
+		if strings.Count(token, ".") != 4 {
+			t.Errorf("expected a compact jwe received %s", token)
+		}
+		tokens[kid] = token
+	}
+	plain, err := inner.Generate(info, time.Now(), 0)
+	if err != nil {
+		t.Fatalf("failed to generate token: %v", err)
+	}
+	//tamper changes a character of the ciphertext
+	tamper := func(token string) string {
+		parts := strings.Split(token, ".")
+		c := "A"
+		if parts[3][0] == 'A' {
+			c = "B"
+		}
+		parts[3] = c + parts[3][1:]
+		return strings.Join(parts, ".")
+	}
+	tests := []struct {
+		token string
+		err   error
+	}{
+		{tokens["dir"], nil},
+		{tokens["rsa"], nil},
+		{plain, ErrMalformed},
+		{tamper(tokens["dir"]), ErrBadSignature},
+		{tamper(tokens["rsa"]), ErrBadSignature},
+	}
+	for ind, test := range tests {
+		claims, err := h.Validate(test.token)
+		if err != test.err {
+			t.Errorf("test %d: expected %v received %v", ind, test.err, err)
+		}
+		if err == nil && !reflect.DeepEqual(info, claims.Custom) {
+			t.Errorf("test %d: expected %+v received %+v", ind, info, claims.Custom)
+		}
+	}
+	keys.Remove("dir")
+	if _, err = h.Validate(tokens["dir"]); err != ErrUnknownKey {
+		t.Errorf("expected %v received %v", ErrUnknownKey, err)
+	}
+}
 
+func TestDPoP(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate ecdsa key: %v", err)
+	}
+	_, other, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate ed25519 key: %v", err)
+	}
+	clock := NewFakeClock(time.Unix(1500000000, 0))
+	v := NewDPoPVerifier(nil, time.Minute)
+	v.SetClock(clock)
+	now := clock.Now()
+	uri := "https://api.example.com/resource"
+	proof := func(key interface{}, method, uri, token string, issuedAt time.Time) string {
+		p, err := NewDPoPProof(key, method, uri, token, issuedAt)
+		if err != nil {
+			t.Fatalf("failed to create proof: %v", err)
+		}
+		return p
+	}
+	hs := jwt.NewWithClaims(jwt.SigningMethodHS256, &dpopClaims{Jti: "1", Htm: "GET", Htu: uri, Iat: now.Unix()})
+	hs.Header["typ"] = dpopType
+	hsProof, err := hs.SignedString([]byte("secret"))
+	if err != nil {
+		t.Fatalf("failed to sign proof: %v", err)
+	}
+	replayed := proof(key, "GET", uri, "", now)
+	tests := []struct {
+		proof  string
+		method string
+		uri    string
+		token  string
+		err    error
+	}{
+		{replayed, "GET", uri, "", nil},
+		{replayed, "GET", uri, "", ErrDPoPReplayed},
+		{proof(other, "GET", uri, "", now), "GET", uri, "", nil},
+		{proof(key, "GET", uri, "", now), "GET", "HTTPS://API.example.com/resource?page=2", "", nil},
+		{proof(key, "GET", uri, "", now), "POST", uri, "", ErrInvalidDPoPProof},
+		{proof(key, "GET", uri, "", now), "GET", "https://api.example.com/other", "", ErrInvalidDPoPProof},
+		{proof(key, "GET", uri, "", now.Add(-2*time.Minute)), "GET", uri, "", ErrInvalidDPoPProof},
+		{proof(key, "GET", uri, "", now.Add(time.Minute)), "GET", uri, "", ErrInvalidDPoPProof},
+		{proof(key, "GET", uri, "", now), "GET", uri, "token", ErrInvalidDPoPProof},
+		{proof(key, "GET", uri, "token", now), "GET", uri, "token", nil},
+		{proof(key, "GET", uri, "token", now), "GET", uri, "stolen", ErrInvalidDPoPProof},
+		{hsProof, "GET", uri, "", ErrInvalidDPoPProof},
+		{"abc", "GET", uri, "", ErrInvalidDPoPProof},
+	}
+	for ind, test := range tests {
+		if _, err = v.Verify(test.proof, test.method, test.uri, test.token); err != test.err {
+			t.Errorf("test %d: expected %v received %v", ind, test.err, err)
+		}
+	}
+	//replayed proofs are forgotten once too old to be accepted
+	clock.Advance(2 * time.Minute)
+	if _, err = v.Verify(replayed, "GET", uri, ""); err != ErrInvalidDPoPProof {
+		t.Errorf("expected %v received %v", ErrInvalidDPoPProof, err)
+	}
+	p, err := v.Verify(proof(key, "GET", uri, "", clock.Now()), "GET", uri, "")
+	if err != nil {
+		t.Fatalf("failed to verify proof: %v", err)
+	}
+	jwk, err := NewJWK("", key.Public())
+	if err != nil {
+		t.Fatalf("failed to create jwk: %v", err)
+	}
+	jkt, err := jwk.Thumbprint()
+	if err != nil || jkt != p.Jkt {
+		t.Errorf("expected thumbprint %s received %s (%v)", jkt, p.Jkt, err)
+	}
+	bound := &AccessToken{Extra: &ExtraClaims{Confirmation: &Confirmation{Jkt: jkt}}}
+	if err = ConfirmDPoP(bound, p); err != nil {
+		t.Errorf("expected nil received %v", err)
+	}
+	if err = ConfirmDPoP(&AccessToken{Extra: &ExtraClaims{}}, p); err != ErrDPoPKeyMismatch {
+		t.Errorf("expected %v received %v", ErrDPoPKeyMismatch, err)
+	}
+	if err = ConfirmDPoP(bound, &DPoPProof{Jkt: "other"}); err != ErrDPoPKeyMismatch {
+		t.Errorf("expected %v received %v", ErrDPoPKeyMismatch, err)
+	}
 }
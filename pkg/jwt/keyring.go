@@ -0,0 +1,280 @@
+package jwt
+
+import (
+	"crypto"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+)
+
+var (
+	errKeyExists   = fmt.Errorf("key id already exists")
+	errKeyRetired  = fmt.Errorf("key is retired")
+	errKeyExpired  = fmt.Errorf("key is expired")
+	errInvalidTTL  = fmt.Errorf("max token validity must be positive")
+	errExpiryShort = fmt.Errorf("verification expiry is before the end of the overlap window")
+)
+
+//RingKey is a signing key of a KeyRing and its lifecycle: it signs from ActivatesAt until RetiresAt (or until a newer key activates) and verifies until ExpiresAt
+type RingKey struct {
+	Kid         string
+	Key         crypto.Signer
+	ActivatesAt time.Time
+	RetiresAt   time.Time
+	ExpiresAt   time.Time
+}
+
+//KeyRing holds rotating signing keys. Only the newest active key signs, retired keys keep verifying until every token they signed has expired
+//Keys are rotated at runtime by calling Stage, Promote and Retire, for instance through the admin handler of the accounts service (accounts.Service.KeyRingHandler)
+type KeyRing struct {
+	mu     sync.RWMutex
+	keys   map[string]*RingKey
+	maxTTL time.Duration
+	clock  Clock
+}
+
+//NewKeyRing returns an empty KeyRing. maxTokenTTL is the longest validity of the tokens signed with the ring: it is the overlap window during which a retired key still verifies
+func NewKeyRing(maxTokenTTL time.Duration) (*KeyRing, error) {
+	if maxTokenTTL <= 0 {
+		return nil, errInvalidTTL
+	}
+	return &KeyRing{keys: map[string]*RingKey{}, maxTTL: maxTokenTTL, clock: SystemClock}, nil
+}
+
+//SetClock replaces the wall clock used to activate, retire and expire keys
+func (kr *KeyRing) SetClock(clock Clock) {
+	if clock == nil {
+		return
+	}
+	kr.mu.Lock()
+	defer kr.mu.Unlock()
+	kr.clock = clock
+}
+
+//Stage adds a key that will start signing at activatesAt (zero value means now). It verifies as soon as it is staged, so that it can be published ahead of its activation
+func (kr *KeyRing) Stage(kid string, key crypto.Signer, activatesAt time.Time) error {
+	if kid == "" {
+		return errEmptyKeyID
+	}
+	if key == nil {
+		return errUnsupportedKeyType
+	}
+	if _, err := signingMethodForKey(key); err != nil {
+		return err
+	}
+	kr.mu.Lock()
+	defer kr.mu.Unlock()
+	if _, ok := kr.keys[kid]; ok {
+		return errKeyExists
+	}
+	if activatesAt.IsZero() {
+		activatesAt = kr.clock.Now()
+	}
+	kr.keys[kid] = &RingKey{Kid: kid, Key: key, ActivatesAt: activatesAt}
+	return nil
+}
+
+//Promote activates a staged key now. The keys that were signing before it are retired
+func (kr *KeyRing) Promote(kid string) error {
+	kr.mu.Lock()
+	defer kr.mu.Unlock()
+	now := kr.clock.Now()
+	k, ok := kr.keys[kid]
+	if !ok {
+		return ErrUnknownKey
+	}
+	if retiredAt := kr.retiredAt(k, now); !retiredAt.IsZero() && !now.Before(retiredAt) {
+		return errKeyRetired
+	}
+	if k.ActivatesAt.After(now) {
+		k.ActivatesAt = now
+	}
+	return nil
+}
+
+//Retire stops signing with a key now. It keeps verifying for the max token validity of the ring
+func (kr *KeyRing) Retire(kid string) error {
+	kr.mu.Lock()
+	defer kr.mu.Unlock()
+	k, ok := kr.keys[kid]
+	if !ok {
+		return ErrUnknownKey
+	}
+	now := kr.clock.Now()
+	if retiredAt := kr.retiredAt(k, now); !retiredAt.IsZero() && !now.Before(retiredAt) {
+		return errKeyRetired
+	}
+	kr.retire(k, now)
+	return nil
+}
+
+//Expire sets the end of the verification window of a key. It can not be before the end of the overlap window
+func (kr *KeyRing) Expire(kid string, at time.Time) error {
+	kr.mu.Lock()
+	defer kr.mu.Unlock()
+	k, ok := kr.keys[kid]
+	if !ok {
+		return ErrUnknownKey
+	}
+	retiresAt := kr.retiredAt(k, kr.clock.Now())
+	if retiresAt.IsZero() {
+		return fmt.Errorf("key '%s' must be retired before it expires", kid)
+	}
+	if at.Before(retiresAt.Add(kr.maxTTL)) {
+		return errExpiryShort
+	}
+	k.ExpiresAt = at
+	return nil
+}
+
+//Purge removes expired keys from the ring
+func (kr *KeyRing) Purge() {
+	kr.mu.Lock()
+	defer kr.mu.Unlock()
+	now := kr.clock.Now()
+	for kid, k := range kr.keys {
+		if kr.expired(k, now) {
+			delete(kr.keys, kid)
+		}
+	}
+}
+
+//Keys returns a snapshot of the keys of the ring sorted by activation time, with their effective retirement and expiry times
+func (kr *KeyRing) Keys() []RingKey {
+	kr.mu.RLock()
+	defer kr.mu.RUnlock()
+	now := kr.clock.Now()
+	res := make([]RingKey, 0, len(kr.keys))
+	for _, k := range kr.keys {
+		rk := *k
+		rk.RetiresAt = kr.retiredAt(k, now)
+		rk.ExpiresAt = kr.expiresAt(k, now)
+		res = append(res, rk)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].ActivatesAt.Equal(res[j].ActivatesAt) {
+			return res[i].Kid < res[j].Kid
+		}
+		return res[i].ActivatesAt.Before(res[j].ActivatesAt)
+	})
+	return res
+}
+
+//Picker returns a SignerPicker that returns the newest active key
+func (kr *KeyRing) Picker() SignerPicker {
+	return func() (string, crypto.Signer) {
+		kr.mu.RLock()
+		defer kr.mu.RUnlock()
+		k := kr.signer(kr.clock.Now())
+		if k == nil {
+			return "", nil
+		}
+		return k.Kid, k.Key
+	}
+}
+
+//KeyFunc returns a jwt.Keyfunc resolving the token's kid header to the public key of a key that is not expired
+func (kr *KeyRing) KeyFunc() jwt.Keyfunc {
+	return func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		if kid == "" {
+			return nil, errMissingKey
+		}
+		kr.mu.RLock()
+		defer kr.mu.RUnlock()
+		k, ok := kr.keys[kid]
+		if !ok {
+			return nil, ErrUnknownKey
+		}
+		if kr.expired(k, kr.clock.Now()) {
+			return nil, errKeyExpired
+		}
+		return k.Key.Public(), nil
+	}
+}
+
+//JWKS returns the public keys of the ring that are not expired, including staged keys
+func (kr *KeyRing) JWKS() (*JWKS, error) {
+	res := &JWKS{Keys: []*JWK{}}
+	kr.mu.RLock()
+	now := kr.clock.Now()
+	kr.mu.RUnlock()
+	for _, k := range kr.Keys() {
+		if !k.ExpiresAt.IsZero() && !now.Before(k.ExpiresAt) {
+			continue
+		}
+		j, err := NewJWK(k.Kid, k.Key.Public())
+		if err != nil {
+			return nil, err
+		}
+		res.Keys = append(res.Keys, j)
+	}
+	return res, nil
+}
+
+//ServeHTTP publishes the JWKS document (mount it on JWKSPath)
+func (kr *KeyRing) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	serveJWKS(w, r, kr.JWKS)
+}
+
+func (kr *KeyRing) retire(k *RingKey, at time.Time) {
+	k.RetiresAt = at
+	if k.ExpiresAt.Before(at.Add(kr.maxTTL)) {
+		k.ExpiresAt = at.Add(kr.maxTTL)
+	}
+}
+
+//signer returns the newest active key. Keys retired by a newer key do not sign again when that key is retired
+func (kr *KeyRing) signer(now time.Time) *RingKey {
+	var res *RingKey
+	for _, k := range kr.keys {
+		if k.ActivatesAt.After(now) {
+			continue
+		}
+		if retiredAt := kr.retiredAt(k, now); !retiredAt.IsZero() && !now.Before(retiredAt) {
+			continue
+		}
+		if res == nil || k.ActivatesAt.After(res.ActivatesAt) || (k.ActivatesAt.Equal(res.ActivatesAt) && k.Kid > res.Kid) {
+			res = k
+		}
+	}
+	return res
+}
+
+//retiredAt returns when a key stopped (or will stop) signing: when it was retired or when a newer key activated. Zero if it still signs
+func (kr *KeyRing) retiredAt(k *RingKey, now time.Time) time.Time {
+	res := k.RetiresAt
+	for _, other := range kr.keys {
+		if other == k || other.ActivatesAt.After(now) || !other.ActivatesAt.After(k.ActivatesAt) {
+			continue
+		}
+		if !other.RetiresAt.IsZero() && !other.RetiresAt.After(other.ActivatesAt) {
+			continue
+		}
+		if res.IsZero() || other.ActivatesAt.Before(res) {
+			res = other.ActivatesAt
+		}
+	}
+	return res
+}
+
+//expiresAt returns when a key stops verifying. Zero if it is not retired
+func (kr *KeyRing) expiresAt(k *RingKey, now time.Time) time.Time {
+	retiredAt := kr.retiredAt(k, now)
+	if retiredAt.IsZero() {
+		return k.ExpiresAt
+	}
+	if k.ExpiresAt.Before(retiredAt.Add(kr.maxTTL)) {
+		return retiredAt.Add(kr.maxTTL)
+	}
+	return k.ExpiresAt
+}
+
+func (kr *KeyRing) expired(k *RingKey, now time.Time) bool {
+	exp := kr.expiresAt(k, now)
+	return !exp.IsZero() && !now.Before(exp)
+}
@@ -0,0 +1,81 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/dgrijalva/jwt-go"
+	"golang.org/x/crypto/ed25519"
+)
+
+var (
+	errUnsupportedKeyType = fmt.Errorf("unsupported key type")
+	errUnexpectedAlg      = fmt.Errorf("unexpected signing algorithm")
+)
+
+//SignerPicker is a function that returns a keyID (string) and a private key (*rsa.PrivateKey, *ecdsa.PrivateKey or ed25519.PrivateKey)
+type SignerPicker func() (string, crypto.Signer)
+
+//signingMethodForKey returns the only signing method accepted for a key. It works with private and public keys:
+//[]byte -> HS256, rsa -> RS256, ecdsa -> ES256/ES384/ES512 depending on the curve, ed25519 -> EdDSA
+func signingMethodForKey(key interface{}) (jwt.SigningMethod, error) {
+	switch k := key.(type) {
+	case []byte:
+		return jwt.SigningMethodHS256, nil
+	case *rsa.PrivateKey, *rsa.PublicKey:
+		return jwt.SigningMethodRS256, nil
+	case *ecdsa.PrivateKey:
+		return ecdsaMethod(k.Curve)
+	case *ecdsa.PublicKey:
+		return ecdsaMethod(k.Curve)
+	case ed25519.PrivateKey, ed25519.PublicKey:
+		return SigningMethodEdDSA, nil
+	default:
+		return nil, errUnsupportedKeyType
+	}
+}
+
+func ecdsaMethod(curve elliptic.Curve) (jwt.SigningMethod, error) {
+	switch curve {
+	case elliptic.P256():
+		return jwt.SigningMethodES256, nil
+	case elliptic.P384():
+		return jwt.SigningMethodES384, nil
+	case elliptic.P521():
+		return jwt.SigningMethodES512, nil
+	default:
+		return nil, errUnsupportedKeyType
+	}
+}
+
+//strictKeyFunc wraps a jwt.Keyfunc and rejects tokens whose alg header does not match the type of the returned key
+func strictKeyFunc(keyFunc jwt.Keyfunc) jwt.Keyfunc {
+	return func(token *jwt.Token) (interface{}, error) {
+		key, err := keyFunc(token)
+		if err != nil {
+			return nil, err
+		}
+		method, err := signingMethodForKey(key)
+		if err != nil {
+			return nil, err
+		}
+		if token.Method == nil || token.Method.Alg() != method.Alg() {
+			return nil, errUnexpectedAlg
+		}
+		return key, nil
+	}
+}
+
+//NewTokenID returns a random token ID (128 bits, hex encoded)
+func NewTokenID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic(fmt.Sprintf("failed to read random bytes: %v", err))
+	}
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,381 @@
+package jwt
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	pb "github.com/klahssen/authn/proto-gen/accounts/apiv1"
+	"golang.org/x/crypto/blake2b"
+	"golang.org/x/crypto/chacha20"
+	"golang.org/x/crypto/ed25519"
+)
+
+//PASETO v4 purposes (https://github.com/paseto-standard/paseto-spec)
+const (
+	PasetoLocal  = "v4.local."
+	PasetoPublic = "v4.public."
+)
+
+const (
+	pasetoNonceSize = 32
+	pasetoMacSize   = 32
+	pasetoKeySize   = 32
+)
+
+var (
+	errInvalidPasetoKey = fmt.Errorf("invalid paseto key")
+	errPasetoMac        = fmt.Errorf("invalid paseto authentication tag")
+)
+
+//pasetoFooter is the unencrypted footer of tokens: it tells which key verifies them
+type pasetoFooter struct {
+	Kid string `json:"kid"`
+}
+
+//pasetoClaims is the payload of tokens: the registered claims are at its top level with ISO 8601 times, as other PASETO implementations expect them.
+//A single audience is encoded as a string
+type pasetoClaims struct {
+	Issuer    string         `json:"iss,omitempty"`
+	Subject   string         `json:"sub,omitempty"`
+	Audience  pasetoAudience `json:"aud,omitempty"`
+	ExpiresAt *time.Time     `json:"exp,omitempty"`
+	NotBefore *time.Time     `json:"nbf,omitempty"`
+	IssuedAt  *time.Time     `json:"iat,omitempty"`
+	Id        string         `json:"jti,omitempty"`
+	Custom    *pb.Info       `json:"account_info"`
+	Extra     *ExtraClaims   `json:"ext,omitempty"`
+}
+
+//pasetoAudience is encoded as a string when it holds a single audience
+type pasetoAudience []string
+
+//MarshalJSON encodes a single audience as a string
+func (a pasetoAudience) MarshalJSON() ([]byte, error) {
+	if len(a) == 1 {
+		return json.Marshal(a[0])
+	}
+	return json.Marshal([]string(a))
+}
+
+//UnmarshalJSON accepts a single audience or a list
+func (a *pasetoAudience) UnmarshalJSON(b []byte) error {
+	return (*Audience)(a).UnmarshalJSON(b)
+}
+
+//newPasetoClaims maps the claims of at to the payload of a token
+func newPasetoClaims(at *AccessToken) *pasetoClaims {
+	pc := &pasetoClaims{Custom: at.Custom, Extra: at.Extra}
+	if std := at.Std; std != nil {
+		pc.Issuer, pc.Subject, pc.Audience, pc.Id = std.Issuer, std.Subject, pasetoAudience(std.Audience), std.Id
+		pc.ExpiresAt = pasetoTime(time.Unix(std.ExpiresAt, 0), std.ExpiresAt != 0)
+		pc.NotBefore = pasetoTime(time.Unix(std.NotBefore, 0), std.NotBefore != 0)
+		pc.IssuedAt = pasetoTime(std.IssuedAtTime(), std.IssuedAt != 0)
+	}
+	return pc
+}
+
+//accessToken maps the payload of a token to its claims
+func (pc *pasetoClaims) accessToken() *AccessToken {
+	std := &Claims{Issuer: pc.Issuer, Subject: pc.Subject, Audience: Audience(pc.Audience), Id: pc.Id}
+	if pc.ExpiresAt != nil {
+		std.ExpiresAt = pc.ExpiresAt.Unix()
+	}
+	if pc.NotBefore != nil {
+		std.NotBefore = pc.NotBefore.Unix()
+	}
+	if pc.IssuedAt != nil {
+		std.IssuedAt = numericDate(*pc.IssuedAt)
+	}
+	return &AccessToken{Std: std, Custom: pc.Custom, Extra: pc.Extra}
+}
+
+//pasetoTime returns t in UTC if set, nil otherwise
+func pasetoTime(t time.Time, set bool) *time.Time {
+	if !set {
+		return nil
+	}
+	t = t.UTC()
+	return &t
+}
+
+//PasetoHandler implements Handler with PASETO v4 tokens: v4.public tokens are signed with Ed25519 keys, v4.local tokens are encrypted with 256 bits symmetric keys.
+//The registered claims are at the top level of the payload with ISO 8601 times, next to the account_info and ext claims of JWT tokens. Tokens carry the key ID in their footer
+type PasetoHandler struct {
+	claimsHandler
+	purpose   string
+	keyPicker func() (string, interface{})
+	keyFunc   jwt.Keyfunc
+}
+
+//NewPasetoLocalHandler returns a Handler issuing v4.local tokens. picker returns 32 bytes keys. keyFunc is called with a token holding only the kid header and must return the key with that ID
+func NewPasetoLocalHandler(issuer, audience, subject string, picker KeyPicker, keyFunc jwt.Keyfunc, stdFunc StdClaimsFunc, customFunc CustomClaimsFunc, validity time.Duration) (*PasetoHandler, error) {
+	if picker == nil {
+		return nil, fmt.Errorf("key picker is nil")
+	}
+	kp := func() (string, interface{}) {
+		return picker()
+	}
+	return newPasetoHandler(PasetoLocal, issuer, audience, subject, kp, keyFunc, stdFunc, customFunc, validity)
+}
+
+//NewPasetoPublicHandler returns a Handler issuing v4.public tokens. picker returns ed25519 private keys and can be nil for services that only verify tokens.
+//keyFunc is called with a token holding only the kid header and must return the ed25519 public key with that ID (see KeySet.KeyFunc and KeyRing.KeyFunc)
+func NewPasetoPublicHandler(issuer, audience, subject string, picker SignerPicker, keyFunc jwt.Keyfunc, stdFunc StdClaimsFunc, customFunc CustomClaimsFunc, validity time.Duration) (*PasetoHandler, error) {
+	var kp func() (string, interface{})
+	if picker != nil {
+		kp = func() (string, interface{}) {
+			return picker()
+		}
+	}
+	return newPasetoHandler(PasetoPublic, issuer, audience, subject, kp, keyFunc, stdFunc, customFunc, validity)
+}
+
+func newPasetoHandler(purpose, issuer, audience, subject string, picker func() (string, interface{}), keyFunc jwt.Keyfunc, stdFunc StdClaimsFunc, customFunc CustomClaimsFunc, validity time.Duration) (*PasetoHandler, error) {
+	if keyFunc == nil {
+		return nil, fmt.Errorf("keyFunc is nil")
+	}
+	if stdFunc == nil {
+		return nil, fmt.Errorf("claimsValidator is nil")
+	}
+	if customFunc == nil {
+		return nil, fmt.Errorf("infoValidator is nil")
+	}
+	return &PasetoHandler{claimsHandler: newClaimsHandler(issuer, audience, subject, stdFunc, customFunc, validity), purpose: purpose, keyPicker: picker, keyFunc: keyFunc}, nil
+}
+
+//Generate returns a PASETO token string: delay is used in not before, t is used for issued at and validity is read from inner value
+func (h *PasetoHandler) Generate(custom *pb.Info, t time.Time, delay time.Duration) (string, error) {
+	return h.GenerateWithParams(custom, t, &TokenParams{Delay: delay})
+}
+
+//GenerateWithParams returns a PASETO token string with the token ID, audiences, delay and extra claims of params
+func (h *PasetoHandler) GenerateWithParams(custom *pb.Info, t time.Time, params *TokenParams) (string, error) {
+	if h.keyPicker == nil {
+		return "", errVerifyOnly
+	}
+	at, err := h.newClaims(custom, t, params)
+	if err != nil {
+		return "", err
+	}
+	payload, err := json.Marshal(newPasetoClaims(at))
+	if err != nil {
+		return "", errFailedToGenerateJwtToken
+	}
+	keyID, key := h.keyPicker()
+	footer, err := json.Marshal(&pasetoFooter{Kid: keyID})
+	if err != nil {
+		return "", errFailedToGenerateJwtToken
+	}
+	var token string
+	switch k := key.(type) {
+	case []byte:
+		if h.purpose != PasetoLocal {
+			return "", errFailedToGenerateJwtToken
+		}
+		nonce := make([]byte, pasetoNonceSize)
+		if _, err = rand.Read(nonce); err != nil {
+			return "", errFailedToGenerateJwtToken
+		}
+		token, err = pasetoEncrypt(k, nonce, payload, footer)
+	case ed25519.PrivateKey:
+		if h.purpose != PasetoPublic {
+			return "", errFailedToGenerateJwtToken
+		}
+		token, err = pasetoSign(k, payload, footer)
+	default:
+		return "", errFailedToGenerateJwtToken
+	}
+	if err != nil {
+		return "", errFailedToGenerateJwtToken
+	}
+	return token, nil
+}
+
+//Validate a token string and return its claims. Errors are one of the Err* values of this package, or the errors returned by stdFunc and customFunc
+func (h *PasetoHandler) Validate(token string) (*AccessToken, error) {
+	if !strings.HasPrefix(token, h.purpose) {
+		return nil, ErrMalformed
+	}
+	parts := strings.Split(token[len(h.purpose):], ".")
+	if len(parts) > 2 {
+		return nil, ErrMalformed
+	}
+	body, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, ErrMalformed
+	}
+	var footer []byte
+	if len(parts) == 2 {
+		if footer, err = base64.RawURLEncoding.DecodeString(parts[1]); err != nil {
+			return nil, ErrMalformed
+		}
+	}
+	f := &pasetoFooter{}
+	if len(footer) > 0 {
+		if err = json.Unmarshal(footer, f); err != nil {
+			return nil, ErrMalformed
+		}
+	}
+	key, err := h.keyFunc(&jwt.Token{Header: map[string]interface{}{"kid": f.Kid}})
+	if err != nil {
+		return nil, keyError(err)
+	}
+	var payload []byte
+	switch k := key.(type) {
+	case []byte:
+		if h.purpose != PasetoLocal {
+			return nil, ErrBadSignature
+		}
+		payload, err = pasetoDecrypt(k, body, footer)
+	case ed25519.PublicKey:
+		if h.purpose != PasetoPublic {
+			return nil, ErrBadSignature
+		}
+		payload, err = pasetoVerify(k, body, footer)
+	default:
+		return nil, ErrBadSignature
+	}
+	if err != nil {
+		return nil, ErrBadSignature
+	}
+	pc := &pasetoClaims{}
+	if err = json.Unmarshal(payload, pc); err != nil {
+		return nil, ErrMalformed
+	}
+	c := pc.accessToken()
+	if err = h.validateClaims(c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+//pae is the Pre-Authentication Encoding of PASETO
+func pae(pieces ...[]byte) []byte {
+	buf := &bytes.Buffer{}
+	le64 := func(n int) {
+		b := make([]byte, 8)
+		binary.LittleEndian.PutUint64(b, uint64(n)&(1<<63-1))
+		buf.Write(b)
+	}
+	le64(len(pieces))
+	for _, p := range pieces {
+		le64(len(p))
+		buf.Write(p)
+	}
+	return buf.Bytes()
+}
+
+func pasetoToken(purpose string, body, footer []byte) string {
+	token := purpose + base64.RawURLEncoding.EncodeToString(body)
+	if len(footer) > 0 {
+		token += "." + base64.RawURLEncoding.EncodeToString(footer)
+	}
+	return token
+}
+
+func pasetoSign(key ed25519.PrivateKey, payload, footer []byte) (string, error) {
+	if len(key) != ed25519.PrivateKeySize {
+		return "", errInvalidPasetoKey
+	}
+	sig := ed25519.Sign(key, pae([]byte(PasetoPublic), payload, footer, nil))
+	return pasetoToken(PasetoPublic, append(payload, sig...), footer), nil
+}
+
+func pasetoVerify(key ed25519.PublicKey, body, footer []byte) ([]byte, error) {
+	if len(key) != ed25519.PublicKeySize {
+		return nil, errInvalidPasetoKey
+	}
+	if len(body) < ed25519.SignatureSize {
+		return nil, ErrMalformed
+	}
+	payload, sig := body[:len(body)-ed25519.SignatureSize], body[len(body)-ed25519.SignatureSize:]
+	if !ed25519.Verify(key, pae([]byte(PasetoPublic), payload, footer, nil), sig) {
+		return nil, ErrBadSignature
+	}
+	return payload, nil
+}
+
+//pasetoKeys derives the encryption key, the XChaCha20 nonce and the authentication key of a v4.local token
+func pasetoKeys(key, nonce []byte) (ek, n2, ak []byte, err error) {
+	if len(key) != pasetoKeySize {
+		return nil, nil, nil, errInvalidPasetoKey
+	}
+	mac, err := blake2b.New(56, key)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	mac.Write([]byte("paseto-encryption-key"))
+	mac.Write(nonce)
+	tmp := mac.Sum(nil)
+	mac, err = blake2b.New(32, key)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	mac.Write([]byte("paseto-auth-key-for-aead"))
+	mac.Write(nonce)
+	return tmp[:32], tmp[32:], mac.Sum(nil), nil
+}
+
+func pasetoTag(ak, nonce, ciphertext, footer []byte) ([]byte, error) {
+	mac, err := blake2b.New(pasetoMacSize, ak)
+	if err != nil {
+		return nil, err
+	}
+	mac.Write(pae([]byte(PasetoLocal), nonce, ciphertext, footer, nil))
+	return mac.Sum(nil), nil
+}
+
+func pasetoEncrypt(key, nonce, payload, footer []byte) (string, error) {
+	ek, n2, ak, err := pasetoKeys(key, nonce)
+	if err != nil {
+		return "", err
+	}
+	stream, err := chacha20.NewUnauthenticatedCipher(ek, n2)
+	if err != nil {
+		return "", err
+	}
+	ciphertext := make([]byte, len(payload))
+	stream.XORKeyStream(ciphertext, payload)
+	tag, err := pasetoTag(ak, nonce, ciphertext, footer)
+	if err != nil {
+		return "", err
+	}
+	body := make([]byte, 0, len(nonce)+len(ciphertext)+len(tag))
+	body = append(append(append(body, nonce...), ciphertext...), tag...)
+	return pasetoToken(PasetoLocal, body, footer), nil
+}
+
+func pasetoDecrypt(key, body, footer []byte) ([]byte, error) {
+	if len(body) < pasetoNonceSize+pasetoMacSize {
+		return nil, ErrMalformed
+	}
+	nonce := body[:pasetoNonceSize]
+	ciphertext := body[pasetoNonceSize : len(body)-pasetoMacSize]
+	tag := body[len(body)-pasetoMacSize:]
+	ek, n2, ak, err := pasetoKeys(key, nonce)
+	if err != nil {
+		return nil, err
+	}
+	expected, err := pasetoTag(ak, nonce, ciphertext, footer)
+	if err != nil {
+		return nil, err
+	}
+	if !hmac.Equal(tag, expected) {
+		return nil, errPasetoMac
+	}
+	stream, err := chacha20.NewUnauthenticatedCipher(ek, n2)
+	if err != nil {
+		return nil, err
+	}
+	payload := make([]byte, len(ciphertext))
+	stream.XORKeyStream(payload, ciphertext)
+	return payload, nil
+}
@@ -0,0 +1,112 @@
+package jwt
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+//RevocationStore holds revoked tokens until they would have expired anyway
+type RevocationStore interface {
+	//Revoke a token by ID (jti) until exp
+	Revoke(id string, exp time.Time) error
+	//RevokeAccount revokes every token of an account issued before t. It is kept until exp
+	RevokeAccount(uid string, t time.Time, exp time.Time) error
+	//IsRevoked checks if a token, or every token of its account, was revoked
+	IsRevoked(id, uid string, issuedAt time.Time) (bool, error)
+}
+
+//Revocable is implemented by handlers checking a RevocationStore when validating tokens
+type Revocable interface {
+	SetRevocationStore(store RevocationStore)
+}
+
+type accountRevocation struct {
+	before time.Time
+	exp    time.Time
+}
+
+//MemRevocationStore is an in-memory RevocationStore. Entries are dropped once the revoked tokens have expired
+type MemRevocationStore struct {
+	mu       sync.RWMutex
+	tokens   map[string]time.Time
+	accounts map[string]accountRevocation
+	clock    Clock
+}
+
+//NewMemRevocationStore returns an empty in-memory RevocationStore
+func NewMemRevocationStore() *MemRevocationStore {
+	return &MemRevocationStore{tokens: map[string]time.Time{}, accounts: map[string]accountRevocation{}, clock: SystemClock}
+}
+
+//SetClock replaces the wall clock used to drop expired entries
+func (m *MemRevocationStore) SetClock(clock Clock) {
+	if clock == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.clock = clock
+}
+
+//Revoke a token by ID until exp
+func (m *MemRevocationStore) Revoke(id string, exp time.Time) error {
+	if id == "" {
+		return fmt.Errorf("token id is empty")
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.purge()
+	if cur, ok := m.tokens[id]; !ok || exp.After(cur) {
+		m.tokens[id] = exp
+	}
+	return nil
+}
+
+//RevokeAccount revokes every token of an account issued before t. t is truncated to the microsecond, the precision of the iat claim
+func (m *MemRevocationStore) RevokeAccount(uid string, t time.Time, exp time.Time) error {
+	if uid == "" {
+		return fmt.Errorf("uid is empty")
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.purge()
+	t = t.Truncate(time.Microsecond)
+	cur := m.accounts[uid]
+	if t.After(cur.before) {
+		cur.before = t
+	}
+	if exp.After(cur.exp) {
+		cur.exp = exp
+	}
+	m.accounts[uid] = cur
+	return nil
+}
+
+//IsRevoked checks if a token, or every token of its account, was revoked
+func (m *MemRevocationStore) IsRevoked(id, uid string, issuedAt time.Time) (bool, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	now := m.clock.Now()
+	if exp, ok := m.tokens[id]; ok && id != "" && now.Before(exp) {
+		return true, nil
+	}
+	if r, ok := m.accounts[uid]; ok && uid != "" && now.Before(r.exp) && issuedAt.Before(r.before) {
+		return true, nil
+	}
+	return false, nil
+}
+
+func (m *MemRevocationStore) purge() {
+	now := m.clock.Now()
+	for id, exp := range m.tokens {
+		if !now.Before(exp) {
+			delete(m.tokens, id)
+		}
+	}
+	for uid, r := range m.accounts {
+		if !now.Before(r.exp) {
+			delete(m.accounts, uid)
+		}
+	}
+}
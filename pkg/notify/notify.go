@@ -0,0 +1,94 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+)
+
+var errEmptyRecipient = fmt.Errorf("message recipient is empty")
+
+//Message is a notification sent to a user
+type Message struct {
+	To      string `json:"to"`
+	Subject string `json:"subject"`
+	Body    string `json:"body"`
+	//Link is an action link included in the body, like a password reset link
+	Link string `json:"link,omitempty"`
+}
+
+//Notifier sends messages to users (by email, SMS...)
+type Notifier interface {
+	Notify(ctx context.Context, msg *Message) error
+}
+
+//MemNotifier keeps messages in memory instead of sending them. It is meant for tests
+type MemNotifier struct {
+	mu       sync.Mutex
+	messages []*Message
+}
+
+//NewMemNotifier returns an empty MemNotifier
+func NewMemNotifier() *MemNotifier {
+	return &MemNotifier{}
+}
+
+//Notify records msg
+func (m *MemNotifier) Notify(ctx context.Context, msg *Message) error {
+	if msg == nil || msg.To == "" {
+		return errEmptyRecipient
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	cp := *msg
+	m.messages = append(m.messages, &cp)
+	return nil
+}
+
+//Messages returns the messages sent to a recipient, oldest first
+func (m *MemNotifier) Messages(to string) []*Message {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var res []*Message
+	for _, msg := range m.messages {
+		if msg.To == to {
+			res = append(res, msg)
+		}
+	}
+	return res
+}
+
+//FileNotifier appends messages to a file, one JSON object per line. It is meant for development and tests
+type FileNotifier struct {
+	mu   sync.Mutex
+	path string
+}
+
+//NewFileNotifier returns a FileNotifier writing to path
+func NewFileNotifier(path string) *FileNotifier {
+	return &FileNotifier{path: path}
+}
+
+//Notify appends msg to the file
+func (f *FileNotifier) Notify(ctx context.Context, msg *Message) error {
+	if msg == nil || msg.To == "" {
+		return errEmptyRecipient
+	}
+	b, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	file, err := os.OpenFile(f.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err = file.Write(append(b, '\n')); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
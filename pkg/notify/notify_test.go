@@ -0,0 +1,55 @@
+package notify
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/klahssen/tester"
+)
+
+func TestNotifiers(t *testing.T) {
+	dir, err := ioutil.TempDir("", "notify")
+	if err != nil {
+		t.Fatalf("failed to create dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "messages.jsonl")
+	mem := NewMemNotifier()
+	te := tester.NewT(t)
+	tests := []struct {
+		msg *Message
+		err error
+	}{
+		{nil, errEmptyRecipient},
+		{&Message{Subject: "hello"}, errEmptyRecipient},
+		{&Message{To: "abc@domain.com", Subject: "hello", Body: "first"}, nil},
+		{&Message{To: "def@domain.com", Subject: "hello", Body: "second"}, nil},
+		{&Message{To: "abc@domain.com", Subject: "hello", Body: "third", Link: "https://domain.com"}, nil},
+	}
+	for ind, test := range tests {
+		for _, n := range []Notifier{mem, NewFileNotifier(path)} {
+			te.CheckError(ind, test.err, n.Notify(context.Background(), test.msg))
+		}
+	}
+	te.DeepEqual(0, "messages", []*Message{tests[2].msg, tests[4].msg}, mem.Messages("abc@domain.com"))
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("failed to open file: %v", err)
+	}
+	defer f.Close()
+	var written []*Message
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		msg := &Message{}
+		if err = json.Unmarshal(s.Bytes(), msg); err != nil {
+			t.Fatalf("invalid line %s: %v", s.Text(), err)
+		}
+		written = append(written, msg)
+	}
+	te.DeepEqual(1, "written messages", []*Message{tests[2].msg, tests[3].msg, tests[4].msg}, written)
+}
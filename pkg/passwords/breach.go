@@ -0,0 +1,153 @@
+package passwords
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	sha1Size         = sha1.Size
+	rangePrefixLen   = 5
+	rangeFileExt     = ".txt"
+	breachLineFormat = "<sha1>:<count>"
+)
+
+var errInvalidBreachLine = fmt.Errorf("invalid breach corpus line, expected %s", breachLineFormat)
+
+//BreachCorpus is an in-memory list of breached passwords, by SHA-1, with the number of times each one appeared in breaches.
+//Hashes are kept sorted in a flat byte slice: 24 bytes per password
+type BreachCorpus struct {
+	hashes []byte
+	counts []uint32
+}
+
+//LoadBreachCorpus reads lines of "<sha1>:<count>" (hex encoded, case insensitive), as written by the Pwned Passwords downloader in a single file
+func LoadBreachCorpus(r io.Reader) (*BreachCorpus, error) {
+	b := &corpusBuilder{}
+	if err := b.read(r, ""); err != nil {
+		return nil, err
+	}
+	return b.build(), nil
+}
+
+//LoadBreachRanges reads a directory partitioned by SHA-1 prefix, like the Pwned Passwords range export: a file "<5 hex chars prefix>.txt" per prefix, with lines of "<35 hex chars suffix>:<count>"
+func LoadBreachRanges(dir string) (*BreachCorpus, error) {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	b := &corpusBuilder{}
+	for _, fi := range files {
+		name := fi.Name()
+		prefix := strings.TrimSuffix(name, rangeFileExt)
+		if fi.IsDir() || filepath.Ext(name) != rangeFileExt || len(prefix) != rangePrefixLen {
+			continue
+		}
+		if strings.Trim(prefix, "0123456789abcdefABCDEF") != "" {
+			continue
+		}
+		f, err := os.Open(filepath.Join(dir, name))
+		if err != nil {
+			return nil, err
+		}
+		err = b.read(f, prefix)
+		f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", name, err)
+		}
+	}
+	return b.build(), nil
+}
+
+//Len returns the number of passwords in the corpus
+func (c *BreachCorpus) Len() int {
+	if c == nil {
+		return 0
+	}
+	return len(c.counts)
+}
+
+//Count returns the number of times pwd appeared in breaches, 0 if it is not in the corpus
+func (c *BreachCorpus) Count(pwd string) int {
+	if c.Len() == 0 {
+		return 0
+	}
+	sum := sha1.Sum([]byte(pwd))
+	n := len(c.counts)
+	i := sort.Search(n, func(i int) bool {
+		return bytes.Compare(c.hashes[i*sha1Size:(i+1)*sha1Size], sum[:]) >= 0
+	})
+	if i < n && bytes.Equal(c.hashes[i*sha1Size:(i+1)*sha1Size], sum[:]) {
+		return int(c.counts[i])
+	}
+	return 0
+}
+
+//corpusBuilder accumulates hashes before sorting them
+type corpusBuilder struct {
+	hashes []byte
+	counts []uint32
+}
+
+//read lines of "<hex>:<count>". The hex part is prefixed with prefix. Entries with a count of 0 are padding and skipped
+func (b *corpusBuilder) read(r io.Reader, prefix string) error {
+	s := bufio.NewScanner(r)
+	line := 0
+	for s.Scan() {
+		line++
+		text := strings.TrimSpace(s.Text())
+		if text == "" {
+			continue
+		}
+		i := strings.IndexByte(text, ':')
+		if i < 0 {
+			return fmt.Errorf("line %d: %v", line, errInvalidBreachLine)
+		}
+		h, err := hex.DecodeString(prefix + text[:i])
+		if err != nil || len(h) != sha1Size {
+			return fmt.Errorf("line %d: %v", line, errInvalidBreachLine)
+		}
+		count, err := strconv.ParseUint(text[i+1:], 10, 32)
+		if err != nil {
+			return fmt.Errorf("line %d: %v", line, errInvalidBreachLine)
+		}
+		if count == 0 {
+			continue
+		}
+		b.hashes = append(b.hashes, h...)
+		b.counts = append(b.counts, uint32(count))
+	}
+	return s.Err()
+}
+
+func (b *corpusBuilder) build() *BreachCorpus {
+	sort.Sort(b)
+	return &BreachCorpus{hashes: b.hashes, counts: b.counts}
+}
+
+func (b *corpusBuilder) Len() int {
+	return len(b.counts)
+}
+
+func (b *corpusBuilder) Less(i, j int) bool {
+	return bytes.Compare(b.hashes[i*sha1Size:(i+1)*sha1Size], b.hashes[j*sha1Size:(j+1)*sha1Size]) < 0
+}
+
+func (b *corpusBuilder) Swap(i, j int) {
+	var tmp [sha1Size]byte
+	hi, hj := b.hashes[i*sha1Size:(i+1)*sha1Size], b.hashes[j*sha1Size:(j+1)*sha1Size]
+	copy(tmp[:], hi)
+	copy(hi, hj)
+	copy(hj, tmp[:])
+	b.counts[i], b.counts[j] = b.counts[j], b.counts[i]
+}
@@ -0,0 +1,67 @@
+package passwords
+
+import (
+	"log"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+//HashPolicy defines how new hashes are computed. Hashes of another algorithm or with lower costs are below the policy and should be replaced on the next successful login
+type HashPolicy struct {
+	//Algorithm of new hashes: AlgBcrypt or AlgArgon2id
+	Algorithm  string
+	BcryptCost int
+	Argon2id   Argon2idParams
+}
+
+//DefaultHashPolicy matches the hashes of HashAndSalt
+var DefaultHashPolicy = HashPolicy{Algorithm: AlgBcrypt, BcryptCost: bcrypt.DefaultCost, Argon2id: DefaultArgon2idParams}
+
+//Hash pwd according to the policy. Unknown algorithms fall back to bcrypt
+func (p HashPolicy) Hash(pwd []byte) string {
+	if p.Algorithm == AlgArgon2id {
+		return Argon2idHashFunc(p.Argon2id)(pwd)
+	}
+	hash, err := bcrypt.GenerateFromPassword(pwd, p.BcryptCost)
+	if err != nil {
+		log.Println(err)
+	}
+	return string(hash)
+}
+
+//NeedsRehash checks if a verified hash is below the policy: computed with another algorithm, lower costs or a shorter salt or key
+func (p HashPolicy) NeedsRehash(hash string) bool {
+	alg := p.Algorithm
+	if alg != AlgArgon2id {
+		alg = AlgBcrypt
+	}
+	if Algorithm(hash) != alg {
+		return true
+	}
+	if alg == AlgBcrypt {
+		cost, err := bcrypt.Cost([]byte(hash))
+		if err != nil {
+			return true
+		}
+		min := p.BcryptCost
+		if min < bcrypt.MinCost {
+			min = bcrypt.DefaultCost
+		}
+		return cost < min
+	}
+	ph, err := parsePHC(hash)
+	if err != nil {
+		return true
+	}
+	params, err := ph.argon2idParams()
+	if err != nil {
+		return true
+	}
+	return params.Memory < p.Argon2id.Memory || params.Time < p.Argon2id.Time || params.Parallelism < p.Argon2id.Parallelism ||
+		params.SaltLength < p.Argon2id.SaltLength || params.KeyLength < p.Argon2id.KeyLength
+}
+
+//NeedsRehash checks a hash against the DefaultHashPolicy
+func NeedsRehash(hash string) bool {
+	return DefaultHashPolicy.NeedsRehash(hash)
+}
@@ -1,9 +1,14 @@
 package passwords
 
 import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/klahssen/tester"
+	"golang.org/x/crypto/bcrypt"
 )
 
 func TestFormat(t *testing.T) {
@@ -42,3 +47,278 @@ func TestCompareHash(t *testing.T) {
 		}
 	}
 }
+
+func TestArgon2id(t *testing.T) {
+	params := Argon2idParams{Memory: 1024, Time: 1, Parallelism: 2, SaltLength: 16, KeyLength: 32}
+	hash, err := HashArgon2id([]byte("abcdef"), params)
+	if err != nil {
+		t.Fatalf("failed to hash password: %v", err)
+	}
+	if !strings.HasPrefix(hash, "$argon2id$v=19$m=1024,t=1,p=2$") {
+		t.Errorf("unexpected hash format %s", hash)
+	}
+	other, err := HashArgon2id([]byte("abcdef"), params)
+	if err != nil {
+		t.Fatalf("failed to hash password: %v", err)
+	}
+	if hash == other {
+		t.Errorf("expected different salts")
+	}
+	if _, err = HashArgon2id([]byte("abcdef"), Argon2idParams{}); err == nil {
+		t.Errorf("expected an error for empty params")
+	}
+	if !CompareHashAndPassword(Argon2idHashFunc(params)([]byte("abcdef")), []byte("abcdef")) {
+		t.Errorf("argon2id hash func: password not matching its hash")
+	}
+}
+
+func TestCompareHashAlgorithms(t *testing.T) {
+	argon, err := HashArgon2id([]byte("password"), Argon2idParams{Memory: 1024, Time: 1, Parallelism: 1, SaltLength: 16, KeyLength: 32})
+	if err != nil {
+		t.Fatalf("failed to hash password: %v", err)
+	}
+	tests := []struct {
+		hash string
+		alg  string
+		ok   bool
+	}{
+		{HashAndSalt([]byte("password")), AlgBcrypt, true},
+		{argon, AlgArgon2id, true},
+		//reference implementation: echo -n password | argon2 somesalt -id -t 2 -m 16 -p 4
+		{"$argon2id$v=19$m=65536,t=2,p=4$c29tZXNhbHQ$GpZ3sK/oH9p7VIiV56G/64Zo/8GaUw434IimaPqxwCo", AlgArgon2id, true},
+		{"$argon2id$v=16$m=65536,t=2,p=4$c29tZXNhbHQ$GpZ3sK/oH9p7VIiV56G/64Zo/8GaUw434IimaPqxwCo", AlgArgon2id, false},
+		//python: hashlib.scrypt and hashlib.pbkdf2_hmac
+		{"$scrypt$ln=10,r=8,p=1$c2FsdHNhbHRzYWx0c2FsdA$BVMRKqdiVYikKAaPR1wucsKUKvw4TuPLkdEYtoSHas4", AlgScrypt, true},
+		{"$pbkdf2-sha256$i=1000,l=32$c2FsdHNhbHRzYWx0c2FsdA$8nX7hwFEzIB8aPajJTYK8weHQc5Ngz0pFVAKvSu4jQA", AlgPBKDF2SHA256, true},
+		{"$pbkdf2-sha256$1000$c2FsdHNhbHRzYWx0c2FsdA$8nX7hwFEzIB8aPajJTYK8weHQc5Ngz0pFVAKvSu4jQA", AlgPBKDF2SHA256, true},
+		{"$pbkdf2-sha256$i=999,l=32$c2FsdHNhbHRzYWx0c2FsdA$8nX7hwFEzIB8aPajJTYK8weHQc5Ngz0pFVAKvSu4jQA", AlgPBKDF2SHA256, false},
+		{"$md5$abc$def", "md5", false},
+		{"password", "", false},
+	}
+	for ind, test := range tests {
+		if alg := Algorithm(test.hash); alg != test.alg {
+			t.Errorf("test %d: expected algorithm %s received %s", ind, test.alg, alg)
+		}
+		if ok := CompareHashAndPassword(test.hash, []byte("password")); ok != test.ok {
+			t.Errorf("test %d: expected %v received %v", ind, test.ok, ok)
+		}
+		if CompareHashAndPassword(test.hash, []byte("wrong")) {
+			t.Errorf("test %d: wrong password accepted", ind)
+		}
+	}
+}
+
+func TestNeedsRehash(t *testing.T) {
+	weak := Argon2idParams{Memory: 1024, Time: 1, Parallelism: 1, SaltLength: 16, KeyLength: 32}
+	strong := Argon2idParams{Memory: 2048, Time: 2, Parallelism: 1, SaltLength: 16, KeyLength: 32}
+	weakArgon, err := HashArgon2id([]byte("password"), weak)
+	if err != nil {
+		t.Fatalf("failed to hash password: %v", err)
+	}
+	strongArgon, err := HashArgon2id([]byte("password"), strong)
+	if err != nil {
+		t.Fatalf("failed to hash password: %v", err)
+	}
+	weakBcrypt, err := bcrypt.GenerateFromPassword([]byte("password"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatalf("failed to hash password: %v", err)
+	}
+	bcryptPolicy := HashPolicy{Algorithm: AlgBcrypt, BcryptCost: bcrypt.DefaultCost}
+	argonPolicy := HashPolicy{Algorithm: AlgArgon2id, Argon2id: strong}
+	tests := []struct {
+		policy HashPolicy
+		hash   string
+		rehash bool
+	}{
+		{bcryptPolicy, HashAndSalt([]byte("password")), false},
+		{bcryptPolicy, string(weakBcrypt), true},
+		{bcryptPolicy, strongArgon, true},
+		{argonPolicy, strongArgon, false},
+		{argonPolicy, weakArgon, true},
+		{argonPolicy, HashAndSalt([]byte("password")), true},
+		{argonPolicy, "$pbkdf2-sha256$i=1000,l=32$c2FsdHNhbHRzYWx0c2FsdA$8nX7hwFEzIB8aPajJTYK8weHQc5Ngz0pFVAKvSu4jQA", true},
+	}
+	for ind, test := range tests {
+		if rehash := test.policy.NeedsRehash(test.hash); rehash != test.rehash {
+			t.Errorf("test %d: expected rehash %v, got %v", ind, test.rehash, rehash)
+		}
+	}
+	for ind, policy := range []HashPolicy{bcryptPolicy, argonPolicy} {
+		hash := policy.Hash([]byte("password"))
+		if !CompareHashAndPassword(hash, []byte("password")) {
+			t.Errorf("policy %d: password not matching its hash", ind)
+		}
+		if policy.NeedsRehash(hash) {
+			t.Errorf("policy %d: new hash below policy", ind)
+		}
+	}
+}
+
+func TestPeppers(t *testing.T) {
+	te := tester.NewT(t)
+	p := NewPeppers()
+	tests := []struct {
+		version string
+		key     []byte
+		err     error
+	}{
+		{"", []byte("0123456789abcdef"), errInvalidPepperVersion},
+		{"a$b", []byte("0123456789abcdef"), errInvalidPepperVersion},
+		{"1", []byte("short"), errInvalidPepper},
+		{"1", []byte("0123456789abcdef"), nil},
+	}
+	for ind, test := range tests {
+		te.CheckError(ind, test.err, p.Add(test.version, test.key))
+	}
+	te.CheckError(len(tests), errMissingPepper, p.SetCurrent("2"))
+	hash := p.HashFunc(HashAndSalt)([]byte("password"))
+	compare := p.CompareFunc(CompareHashAndPassword)
+	if !compare(hash, []byte("password")) || compare(hash, []byte("passwore")) {
+		t.Errorf("unexpected comparison result for %s", hash)
+	}
+	if compare("$pepper$v=2$"+hash[len("$pepper$v=1$"):], []byte("password")) {
+		t.Errorf("hash of an unknown pepper accepted")
+	}
+	p.Remove("1")
+	if h := p.HashFunc(HashAndSalt)([]byte("password")); h != "" {
+		t.Errorf("expected empty hash without current pepper, received %s", h)
+	}
+}
+
+func TestPolicy(t *testing.T) {
+	p := &Policy{MinLength: 8, MaxLength: 12, Classes: []CharClass{Upper, Lower, Digit, Symbol}, MaxRepeated: 2, NoEmailLocalPart: true, NFKC: true}
+	tests := []struct {
+		pwd   string
+		email string
+		rules []string
+	}{
+		{"Abcdef1!", "john@domain.com", nil},
+		//5 runes but 10 bytes
+		{"Ébcé1", "", []string{RuleLength, RuleClass}},
+		{"abcdefgh", "", []string{RuleClass, RuleClass, RuleClass}},
+		{"Abcdef1!aaa", "", []string{RuleRepeated}},
+		{"xJohn1!xyz", "john@domain.com", []string{RuleEmail}},
+		{"Ab1!jo", "jo@domain.com", []string{RuleLength}},
+		//NFKC: the fullwidth digit is a digit, the ligature is 2 characters
+		{"Abcd!ﬀ１", "", nil},
+		{"Abcdefgh1!xyz", "", []string{RuleLength}},
+	}
+	for ind, test := range tests {
+		violations := p.Check(test.pwd, test.email)
+		var rules []string
+		for _, v := range violations {
+			rules = append(rules, v.Rule)
+		}
+		if strings.Join(rules, ",") != strings.Join(test.rules, ",") {
+			t.Errorf("test %d: expected violations %v, received %v", ind, test.rules, rules)
+		}
+	}
+	if v := DefaultPolicy.Check("abc", ""); len(v) != 1 || v[0].Error() != "invalid length: min 5, max 40 characters" {
+		t.Errorf("unexpected default policy violations %v", v)
+	}
+}
+
+func TestBreachCorpus(t *testing.T) {
+	//sha1 of "P@ssw0rd" and "password_003"
+	corpus, err := LoadBreachCorpus(strings.NewReader("21BD12DC183F740EE76F27B78EB39C8AD972A757:12\r\nae9238f7d6b2a0271f78f24195a683868004c282:3\n0000000000000000000000000000000000000000:0\n"))
+	if err != nil {
+		t.Fatalf("failed to load corpus: %v", err)
+	}
+	dir, err := ioutil.TempDir("", "breach")
+	if err != nil {
+		t.Fatalf("failed to create dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	files := map[string]string{
+		"21BD1.txt":  "2DC183F740EE76F27B78EB39C8AD972A757:12\n",
+		"AE923.txt":  "8f7d6b2a0271f78f24195a683868004c282:3\nFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFF:0\n",
+		"README.md":  "not a range",
+		"12345.json": "{}",
+	}
+	for name, content := range files {
+		if err = ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0600); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+	ranges, err := LoadBreachRanges(dir)
+	if err != nil {
+		t.Fatalf("failed to load ranges: %v", err)
+	}
+	for ind, c := range []*BreachCorpus{corpus, ranges} {
+		if c.Len() != 2 {
+			t.Errorf("corpus %d: expected 2 passwords, received %d", ind, c.Len())
+		}
+		for pwd, count := range map[string]int{"P@ssw0rd": 12, "password_003": 3, "password_004": 0} {
+			if n := c.Count(pwd); n != count {
+				t.Errorf("corpus %d: expected %s found %d times, received %d", ind, pwd, count, n)
+			}
+		}
+	}
+	var empty *BreachCorpus
+	if empty.Count("P@ssw0rd") != 0 {
+		t.Errorf("nil corpus matching a password")
+	}
+	for ind, line := range []string{"21BD12DC183F740EE76F27B78EB39C8AD972A757", "21BD12DC:1", "21BD12DC183F740EE76F27B78EB39C8AD972A757:x"} {
+		if _, err = LoadBreachCorpus(strings.NewReader(line)); err == nil {
+			t.Errorf("test %d: expected an error for line %s", ind, line)
+		}
+	}
+	p := &Policy{Breached: corpus}
+	if v := p.Check("P@ssw0rd", ""); len(v) != 1 || v[0].Rule != RuleBreached || v[0].Description != "found 12 times in data breaches" {
+		t.Errorf("unexpected violations %v", v)
+	}
+	//sha1 of the fullwidth "ｐａｓｓｗｏｒｄ": it is found before NFKC normalization
+	fullwidth, err := LoadBreachCorpus(strings.NewReader("F0BD080F4D3F55DF783B81E795E180E74BAC516C:5\n"))
+	if err != nil {
+		t.Fatalf("failed to load corpus: %v", err)
+	}
+	p = &Policy{Breached: fullwidth, NFKC: true}
+	if v := p.Check("ｐａｓｓｗｏｒｄ", ""); len(v) != 1 || v[0].Rule != RuleBreached {
+		t.Errorf("unexpected violations %v", v)
+	}
+}
+
+func TestEstimateStrength(t *testing.T) {
+	tests := []struct {
+		pwd     string
+		score   int
+		warning string
+	}{
+		{"Password1!", 0, "This is a very common password"},
+		{"password", 0, "This is a top-10 common password"},
+		{"p@ssw0rd", 0, "This is a very common password"},
+		{"drowssap", 0, "This is a very common password"},
+		{"zxcvbnm,./", 1, "Straight rows of keys are easy to guess"},
+		{"abcdefgh", 0, "Sequences like abc or 6543 are easy to guess"},
+		{"aaaaaaaa", 0, "Repeats like \"aaa\" are easy to guess"},
+		{"xyzxyzxyz", 0, "Repeats like \"abcabcabc\" are only slightly harder to guess than \"abc\""},
+		{"13.05.1987", 1, "Dates are often easy to guess"},
+		{"jsmith", 0, "Avoid using personal information like your email"},
+		{"kjH7#mq2!Lp9zR", 4, ""},
+		{"correct horse battery staple", 4, ""},
+	}
+	for ind, test := range tests {
+		s := EstimateStrength(test.pwd, "john.smith@example.com")
+		if s.Score != test.score {
+			t.Errorf("test %d: expected score %d for %s, received %d (%g guesses)", ind, test.score, test.pwd, s.Score, s.Guesses)
+		}
+		if s.Warning != test.warning {
+			t.Errorf("test %d: expected warning %q, received %q", ind, test.warning, s.Warning)
+		}
+		if s.Score <= 2 && len(s.Suggestions) == 0 {
+			t.Errorf("test %d: expected suggestions for a weak password", ind)
+		}
+		if s.Score > 2 && len(s.Hints()) > 0 {
+			t.Errorf("test %d: unexpected hints %v", ind, s.Hints())
+		}
+	}
+	p := &Policy{MinScore: 3}
+	if v := p.Check("Password1!", ""); len(v) != 1 || v[0].Rule != RuleStrength || len(v[0].Hints) == 0 {
+		t.Errorf("unexpected violations %v", v)
+	}
+	//passwords of invalid length are not estimated
+	p.MaxLength = 40
+	if v := p.Check(strings.Repeat("1!|7", 30), ""); len(v) != 1 || v[0].Rule != RuleLength {
+		t.Errorf("unexpected violations %v", v)
+	}
+}
@@ -7,7 +7,7 @@ import (
 	"golang.org/x/crypto/bcrypt"
 )
 
-var errInvalidFormat = fmt.Errorf("invalid length: min 5, max 64 characters")
+var errInvalidFormat = fmt.Errorf("invalid length: min 5, max 40 characters")
 
 func ErrIsInvalidFormat(err error) bool {
 	return err == errInvalidFormat
@@ -39,11 +39,8 @@ func HashAndSalt(pwd []byte) string {
 	return string(hash)
 }
 
-//CompareHashAndCleartext returns nil for matching hash and password
+//CompareHashAndPassword returns true for matching hash and password. The algorithm is read from the hash prefix: bcrypt, argon2id, scrypt or pbkdf2-sha256
 func CompareHashAndPassword(hash string, pwd []byte) bool {
-	// Since we'll be getting the hashed password from the DB it
-	// will be a string so we'll need to convert it to a byte slice
-	byteHash := []byte(hash)
-	err := bcrypt.CompareHashAndPassword(byteHash, pwd)
-	return err == nil
+	ok, err := compareHash(hash, pwd)
+	return err == nil && ok
 }
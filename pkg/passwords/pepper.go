@@ -0,0 +1,144 @@
+package passwords
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+)
+
+//AlgPepper identifies peppered hashes: $pepper$v=<version>$<hash of the peppered password>
+const AlgPepper = "pepper"
+
+const pepperMinSize = 16
+
+var (
+	errInvalidPepperVersion = fmt.Errorf("pepper version must be non empty and can not contain '$'")
+	errInvalidPepper        = fmt.Errorf("pepper must be at least 16 bytes")
+	errMissingPepper        = fmt.Errorf("pepper not found")
+	errNoPepper             = fmt.Errorf("no current pepper")
+)
+
+//Peppers holds secret HMAC keys applied to passwords before hashing, by version. They must be kept outside the datastore holding the hashes.
+//New hashes use the current pepper and record its version, so peppers can be rotated: hashes of previous versions are checked with their pepper and rehashed on login
+//An AccountValidator uses peppers with its three functions, the rehash one set last as SetHashFunc disables rehashing: SetHashFunc(p.HashFunc(HashAndSalt)), SetAuthnFunc(p.CompareFunc(CompareHashAndPassword)) and SetRehashFunc(p.RehashFunc(NeedsRehash))
+type Peppers struct {
+	mu      sync.RWMutex
+	keys    map[string][]byte
+	current string
+}
+
+//NewPeppers returns an empty set of peppers
+func NewPeppers() *Peppers {
+	return &Peppers{keys: map[string][]byte{}}
+}
+
+//Add a pepper. The first pepper added becomes the current one
+func (p *Peppers) Add(version string, key []byte) error {
+	if version == "" || strings.Contains(version, "$") {
+		return errInvalidPepperVersion
+	}
+	if len(key) < pepperMinSize {
+		return errInvalidPepper
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.keys[version] = append([]byte(nil), key...)
+	if p.current == "" {
+		p.current = version
+	}
+	return nil
+}
+
+//SetCurrent selects the pepper of new hashes
+func (p *Peppers) SetCurrent(version string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if _, ok := p.keys[version]; !ok {
+		return errMissingPepper
+	}
+	p.current = version
+	return nil
+}
+
+//Remove a pepper: hashes using it can not be checked anymore
+func (p *Peppers) Remove(version string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.keys, version)
+	if p.current == version {
+		p.current = ""
+	}
+}
+
+//HashFunc returns a function peppering passwords with the current pepper before hashing them with hash (ex: HashAndSalt)
+func (p *Peppers) HashFunc(hash func(pwd []byte) string) func(pwd []byte) string {
+	return func(pwd []byte) string {
+		p.mu.RLock()
+		version, key := p.current, p.keys[p.current]
+		p.mu.RUnlock()
+		if key == nil {
+			log.Println(errNoPepper)
+			return ""
+		}
+		h := hash(pepper(key, pwd))
+		if h == "" {
+			return ""
+		}
+		return fmt.Sprintf("$%s$v=%s%s", AlgPepper, version, h)
+	}
+}
+
+//CompareFunc returns a function checking passwords against peppered hashes with compare (ex: CompareHashAndPassword). Hashes without pepper are compared as is
+func (p *Peppers) CompareFunc(compare func(hash string, pwd []byte) bool) func(hash string, pwd []byte) bool {
+	return func(hash string, pwd []byte) bool {
+		version, inner, ok := splitPeppered(hash)
+		if !ok {
+			return compare(hash, pwd)
+		}
+		p.mu.RLock()
+		key := p.keys[version]
+		p.mu.RUnlock()
+		if key == nil {
+			return false
+		}
+		return compare(inner, pepper(key, pwd))
+	}
+}
+
+//RehashFunc returns a function checking if a hash is below the policy: without pepper, with a pepper that is not the current one, or with an inner hash below the policy of needsRehash (ex: NeedsRehash)
+func (p *Peppers) RehashFunc(needsRehash func(hash string) bool) func(hash string) bool {
+	return func(hash string) bool {
+		version, inner, ok := splitPeppered(hash)
+		if !ok {
+			return true
+		}
+		p.mu.RLock()
+		current := p.current
+		p.mu.RUnlock()
+		return version != current || needsRehash(inner)
+	}
+}
+
+//pepper returns the base64 encoded HMAC-SHA256 of pwd. Encoding keeps it below the 72 bytes limit of bcrypt and free of NUL bytes
+func pepper(key, pwd []byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(pwd)
+	return []byte(b64(mac.Sum(nil)))
+}
+
+//splitPeppered returns the pepper version and inner hash of a peppered hash
+func splitPeppered(hash string) (string, string, bool) {
+	prefix := "$" + AlgPepper + "$v="
+	if !strings.HasPrefix(hash, prefix) {
+		return "", "", false
+	}
+	rest := hash[len(prefix):]
+	i := strings.Index(rest, "$")
+	if i <= 0 {
+		return "", "", false
+	}
+	return rest[:i], rest[i:], true
+}
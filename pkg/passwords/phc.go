@@ -0,0 +1,215 @@
+package passwords
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/crypto/scrypt"
+)
+
+//Hash algorithms, as identified in PHC strings (https://github.com/P-H-C/phc-string-format)
+const (
+	AlgBcrypt       = "bcrypt"
+	AlgArgon2id     = "argon2id"
+	AlgScrypt       = "scrypt"
+	AlgPBKDF2SHA256 = "pbkdf2-sha256"
+)
+
+var (
+	errInvalidHash      = fmt.Errorf("invalid hash")
+	errUnsupportedHash  = fmt.Errorf("unsupported hash algorithm")
+	errIncompatibleHash = fmt.Errorf("incompatible argon2 version")
+)
+
+//Argon2idParams are the cost parameters of argon2id hashes. Memory is in KiB
+type Argon2idParams struct {
+	Memory      uint32
+	Time        uint32
+	Parallelism uint8
+	SaltLength  uint32
+	KeyLength   uint32
+}
+
+//DefaultArgon2idParams follow the second recommended option of RFC 9106, with 64 MiB of memory
+var DefaultArgon2idParams = Argon2idParams{Memory: 64 * 1024, Time: 3, Parallelism: 4, SaltLength: 16, KeyLength: 32}
+
+//HashArgon2id hashes pwd with argon2id and returns the hash as a PHC string: $argon2id$v=19$m=<memory>,t=<time>,p=<parallelism>$<salt>$<hash>
+func HashArgon2id(pwd []byte, params Argon2idParams) (string, error) {
+	if params.Memory == 0 || params.Time == 0 || params.Parallelism == 0 || params.SaltLength == 0 || params.KeyLength == 0 {
+		return "", fmt.Errorf("invalid argon2id params")
+	}
+	salt := make([]byte, params.SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+	key := argon2.IDKey(pwd, salt, params.Time, params.Memory, params.Parallelism, params.KeyLength)
+	return fmt.Sprintf("$%s$v=%d$m=%d,t=%d,p=%d$%s$%s", AlgArgon2id, argon2.Version, params.Memory, params.Time, params.Parallelism, b64(salt), b64(key)), nil
+}
+
+//Argon2idHashFunc returns a function hashing passwords with argon2id, with the signature of HashAndSalt
+func Argon2idHashFunc(params Argon2idParams) func(pwd []byte) string {
+	return func(pwd []byte) string {
+		hash, err := HashArgon2id(pwd, params)
+		if err != nil {
+			log.Println(err)
+		}
+		return hash
+	}
+}
+
+//Algorithm returns the algorithm of a hash: bcrypt hashes use the modular crypt format, other hashes are PHC strings
+func Algorithm(hash string) string {
+	switch {
+	case strings.HasPrefix(hash, "$2a$"), strings.HasPrefix(hash, "$2b$"), strings.HasPrefix(hash, "$2y$"):
+		return AlgBcrypt
+	case strings.HasPrefix(hash, "$"):
+		parts := strings.SplitN(hash[1:], "$", 2)
+		return parts[0]
+	}
+	return ""
+}
+
+//compareHash checks pwd against a hash of any supported algorithm
+func compareHash(hash string, pwd []byte) (bool, error) {
+	alg := Algorithm(hash)
+	if alg == AlgBcrypt {
+		return bcrypt.CompareHashAndPassword([]byte(hash), pwd) == nil, nil
+	}
+	p, err := parsePHC(hash)
+	if err != nil {
+		return false, err
+	}
+	var key []byte
+	switch alg {
+	case AlgArgon2id:
+		params, err := p.argon2idParams()
+		if err != nil {
+			return false, err
+		}
+		key = argon2.IDKey(pwd, p.salt, params.Time, params.Memory, params.Parallelism, uint32(len(p.hash)))
+	case AlgScrypt:
+		ln, err := p.intParam("ln")
+		if err != nil || ln <= 0 || ln >= 32 {
+			return false, errInvalidHash
+		}
+		r, err := p.intParam("r")
+		if err != nil {
+			return false, err
+		}
+		par, err := p.intParam("p")
+		if err != nil {
+			return false, err
+		}
+		if key, err = scrypt.Key(pwd, p.salt, 1<<uint(ln), r, par, len(p.hash)); err != nil {
+			return false, errInvalidHash
+		}
+	case AlgPBKDF2SHA256:
+		iter, err := p.intParam("i")
+		if err != nil || iter <= 0 {
+			return false, errInvalidHash
+		}
+		key = pbkdf2.Key(pwd, p.salt, iter, len(p.hash), sha256.New)
+	default:
+		return false, errUnsupportedHash
+	}
+	return subtle.ConstantTimeCompare(key, p.hash) == 1, nil
+}
+
+//phc is a parsed PHC string: $<id>[$v=<version>][$<param>=<value>(,<param>=<value>)*][$<salt>[$<hash>]]
+type phc struct {
+	id      string
+	version int
+	params  map[string]string
+	salt    []byte
+	hash    []byte
+}
+
+func parsePHC(s string) (*phc, error) {
+	if !strings.HasPrefix(s, "$") {
+		return nil, errInvalidHash
+	}
+	parts := strings.Split(s[1:], "$")
+	p := &phc{id: parts[0], params: map[string]string{}}
+	parts = parts[1:]
+	if len(parts) > 0 && strings.HasPrefix(parts[0], "v=") {
+		v, err := strconv.Atoi(parts[0][2:])
+		if err != nil {
+			return nil, errInvalidHash
+		}
+		p.version = v
+		parts = parts[1:]
+	}
+	if len(parts) != 3 {
+		return nil, errInvalidHash
+	}
+	for _, kv := range strings.Split(parts[0], ",") {
+		i := strings.Index(kv, "=")
+		if i <= 0 {
+			//passlib encodes the pbkdf2 rounds without a name
+			if p.id == AlgPBKDF2SHA256 && len(p.params) == 0 {
+				p.params["i"] = kv
+				continue
+			}
+			return nil, errInvalidHash
+		}
+		p.params[kv[:i]] = kv[i+1:]
+	}
+	var err error
+	if p.salt, err = unb64(parts[1]); err != nil {
+		return nil, errInvalidHash
+	}
+	if p.hash, err = unb64(parts[2]); err != nil || len(p.hash) == 0 {
+		return nil, errInvalidHash
+	}
+	return p, nil
+}
+
+func (p *phc) intParam(name string) (int, error) {
+	v, ok := p.params[name]
+	if !ok {
+		return 0, errInvalidHash
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n <= 0 {
+		return 0, errInvalidHash
+	}
+	return n, nil
+}
+
+func (p *phc) argon2idParams() (*Argon2idParams, error) {
+	if p.version != argon2.Version {
+		return nil, errIncompatibleHash
+	}
+	m, err := p.intParam("m")
+	if err != nil {
+		return nil, err
+	}
+	t, err := p.intParam("t")
+	if err != nil {
+		return nil, err
+	}
+	par, err := p.intParam("p")
+	if err != nil || par > 255 {
+		return nil, errInvalidHash
+	}
+	return &Argon2idParams{Memory: uint32(m), Time: uint32(t), Parallelism: uint8(par), SaltLength: uint32(len(p.salt)), KeyLength: uint32(len(p.hash))}, nil
+}
+
+//b64 encodes with the PHC base64 alphabet: standard, without padding
+func b64(b []byte) string {
+	return base64.RawStdEncoding.EncodeToString(b)
+}
+
+//unb64 decodes PHC base64, and the adapted base64 of passlib ('.' instead of '+')
+func unb64(s string) ([]byte, error) {
+	return base64.RawStdEncoding.DecodeString(strings.Replace(strings.TrimRight(s, "="), ".", "+", -1))
+}
@@ -0,0 +1,163 @@
+package passwords
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+//CharClass is a class of characters a password can be required to contain
+type CharClass string
+
+//Character classes
+const (
+	Upper  CharClass = "uppercase"
+	Lower  CharClass = "lowercase"
+	Digit  CharClass = "digit"
+	Symbol CharClass = "symbol"
+)
+
+//Policy rules, named in violations
+const (
+	RuleLength   = "length"
+	RuleClass    = "class"
+	RuleRepeated = "repeated"
+	RuleEmail    = "email"
+	RuleBreached = "breached"
+	RuleStrength = "strength"
+)
+
+//emailLocalMinLength is the shortest email local-part banned from passwords: shorter ones would ban too many passwords
+const emailLocalMinLength = 3
+
+//Policy declares the rules passwords must follow. Zero values disable rules
+type Policy struct {
+	//MinLength and MaxLength are counted in runes, after normalization
+	MinLength int
+	MaxLength int
+	//Classes of characters passwords must contain
+	Classes []CharClass
+	//MaxRepeated is the maximum number of consecutive identical characters
+	MaxRepeated int
+	//NoEmailLocalPart rejects passwords containing the local-part of the account's email (case insensitive)
+	NoEmailLocalPart bool
+	//NFKC normalizes passwords (Unicode NFKC) before they are checked, hashed and compared
+	NFKC bool
+	//Breached rejects passwords found in the corpus, reporting how many times they appeared in breaches
+	Breached *BreachCorpus
+	//MinScore is the minimum strength score (0 to 4) of passwords, estimated with the email as user input
+	MinScore int
+}
+
+//DefaultPolicy only checks the length of passwords, like ValidateFormat
+var DefaultPolicy = Policy{MinLength: 5, MaxLength: 40}
+
+//Violation is a rule a password does not follow
+type Violation struct {
+	Rule        string
+	Description string
+	//Hints help to follow the rule
+	Hints []string
+}
+
+func (v *Violation) Error() string {
+	return v.Description
+}
+
+//Normalize pwd if the policy requires it
+func (p *Policy) Normalize(pwd string) string {
+	if !p.NFKC {
+		return pwd
+	}
+	return norm.NFKC.String(pwd)
+}
+
+//Check a password of an account with email against every rule of the policy and return all violations
+func (p *Policy) Check(pwd, email string) []*Violation {
+	raw := pwd
+	pwd = p.Normalize(pwd)
+	var violations []*Violation
+	l := utf8.RuneCountInString(pwd)
+	validLength := (p.MinLength <= 0 || l >= p.MinLength) && (p.MaxLength <= 0 || l <= p.MaxLength)
+	if !validLength {
+		violations = append(violations, &Violation{Rule: RuleLength, Description: p.lengthDescription()})
+	}
+	for _, class := range p.Classes {
+		if strings.IndexFunc(pwd, class.contains) < 0 {
+			violations = append(violations, &Violation{Rule: RuleClass, Description: fmt.Sprintf("must contain a %s character", class)})
+		}
+	}
+	if p.MaxRepeated > 0 && maxRepeated(pwd) > p.MaxRepeated {
+		violations = append(violations, &Violation{Rule: RuleRepeated, Description: fmt.Sprintf("max %d repeated characters", p.MaxRepeated)})
+	}
+	if p.NoEmailLocalPart {
+		local := email
+		if i := strings.LastIndex(email, "@"); i >= 0 {
+			local = email[:i]
+		}
+		local = strings.ToLower(p.Normalize(local))
+		if utf8.RuneCountInString(local) >= emailLocalMinLength && strings.Contains(strings.ToLower(pwd), local) {
+			violations = append(violations, &Violation{Rule: RuleEmail, Description: "must not contain the email"})
+		}
+	}
+	//breach corpora hold hashes of the passwords as typed
+	n := p.Breached.Count(pwd)
+	if n == 0 && raw != pwd {
+		n = p.Breached.Count(raw)
+	}
+	if n > 0 {
+		violations = append(violations, &Violation{Rule: RuleBreached, Description: fmt.Sprintf("found %d times in data breaches", n)})
+	}
+	//the estimation is the most expensive rule: it is skipped for passwords of invalid length
+	if p.MinScore > 0 && validLength {
+		if s := EstimateStrength(pwd, email); s.Score < p.MinScore {
+			violations = append(violations, &Violation{Rule: RuleStrength, Description: fmt.Sprintf("too weak: score %d, min %d", s.Score, p.MinScore), Hints: s.Hints()})
+		}
+	}
+	return violations
+}
+
+func (p *Policy) lengthDescription() string {
+	switch {
+	case p.MaxLength <= 0:
+		return fmt.Sprintf("invalid length: min %d characters", p.MinLength)
+	case p.MinLength <= 0:
+		return fmt.Sprintf("invalid length: max %d characters", p.MaxLength)
+	}
+	return fmt.Sprintf("invalid length: min %d, max %d characters", p.MinLength, p.MaxLength)
+}
+
+func (c CharClass) contains(r rune) bool {
+	switch c {
+	case Upper:
+		return unicode.IsUpper(r)
+	case Lower:
+		return unicode.IsLower(r)
+	case Digit:
+		return unicode.IsDigit(r)
+	case Symbol:
+		return unicode.IsPunct(r) || unicode.IsSymbol(r)
+	}
+	return false
+}
+
+//maxRepeated returns the length of the longest run of identical runes
+func maxRepeated(s string) int {
+	max, n := 0, 0
+	var prev rune
+	for i, r := range s {
+		if i > 0 && r == prev {
+			n++
+		} else {
+			n = 1
+		}
+		if n > max {
+			max = n
+		}
+		prev = r
+	}
+	return max
+}
@@ -0,0 +1,627 @@
+package passwords
+
+import (
+	"math"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+//Strength is the estimated strength of a password, inspired by zxcvbn: the password is split in the patterns an attacker would guess first (dictionary words, keyboard patterns, sequences, repeats and dates), other characters are brute forced.
+//Score goes from 0 (too guessable) to 4 (very unguessable). Warning and Suggestions give feedback on weak passwords
+type Strength struct {
+	Score       int
+	Guesses     float64
+	Warning     string
+	Suggestions []string
+}
+
+//Hints returns the warning followed by the suggestions
+func (s *Strength) Hints() []string {
+	var hints []string
+	if s.Warning != "" {
+		hints = append(hints, s.Warning)
+	}
+	return append(hints, s.Suggestions...)
+}
+
+//patterns of matches
+const (
+	patternDictionary = "dictionary"
+	patternUserInput  = "user_input"
+	patternKeyboard   = "keyboard"
+	patternSequence   = "sequence"
+	patternRepeat     = "repeat"
+	patternDate       = "date"
+)
+
+const (
+	//strengthMaxRunes bounds the cost of estimations: longer passwords are estimated on their prefix
+	strengthMaxRunes  = 64
+	bruteforcePerRune = 10
+	minSingleGuesses  = 10
+	minMultiGuesses   = 50
+	keyboardStarts    = 94
+	keyboardDegree    = 4
+	minYearSpace      = 20
+	userInputMinRunes = 3
+)
+
+//score thresholds, in guesses (zxcvbn)
+var scoreThresholds = []float64{1e3 + 5, 1e6 + 5, 1e8 + 5, 1e10 + 5}
+
+//match is a guessable part of a password, from rune i to rune j included
+type match struct {
+	pattern string
+	i, j    int
+	token   string
+	guesses float64
+	rank    int
+	l33t    bool
+	upper   bool
+	reverse bool
+	block   string
+}
+
+//EstimateStrength of pwd. userInputs are words specific to the user, like the email: they are guessed first
+func EstimateStrength(pwd string, userInputs ...string) *Strength {
+	runes := []rune(pwd)
+	if len(runes) > strengthMaxRunes {
+		runes = runes[:strengthMaxRunes]
+	}
+	guesses, seq := mostGuessable(runes, userDictionary(userInputs))
+	s := &Strength{Score: len(scoreThresholds), Guesses: guesses}
+	for i, t := range scoreThresholds {
+		if guesses < t {
+			s.Score = i
+			break
+		}
+	}
+	s.feedback(seq, len(runes))
+	return s
+}
+
+//mostGuessable returns the guesses needed for the cheapest split of runes in matches and brute forced characters, and the matches of that split
+func mostGuessable(runes []rune, user map[string]int) (float64, []*match) {
+	n := len(runes)
+	if n == 0 {
+		return 1, nil
+	}
+	byEnd := make([][]*match, n)
+	for _, m := range findMatches(runes, user) {
+		byEnd[m.j] = append(byEnd[m.j], m)
+	}
+	//best[k] is the log10 of the guesses of runes[:k]
+	best := make([]float64, n+1)
+	prev := make([]*match, n+1)
+	for k := 1; k <= n; k++ {
+		best[k] = best[k-1] + math.Log10(bruteforcePerRune)
+		prev[k] = nil
+		for _, m := range byEnd[k-1] {
+			if g := best[m.i] + math.Log10(m.guesses); g < best[k] {
+				best[k] = g
+				prev[k] = m
+			}
+		}
+	}
+	var seq []*match
+	for k := n; k > 0; {
+		if m := prev[k]; m != nil {
+			seq = append([]*match{m}, seq...)
+			k = m.i
+			continue
+		}
+		k--
+	}
+	return math.Pow(10, best[n]), seq
+}
+
+func findMatches(runes []rune, user map[string]int) []*match {
+	var matches []*match
+	lower := []rune(strings.ToLower(string(runes)))
+	if len(lower) != len(runes) {
+		lower = runes
+	}
+	matches = append(matches, dictionaryMatches(runes, lower, user)...)
+	matches = append(matches, keyboardMatches(runes, lower)...)
+	matches = append(matches, sequenceMatches(runes)...)
+	matches = append(matches, repeatMatches(runes, user)...)
+	matches = append(matches, dateMatches(runes)...)
+	for _, m := range matches {
+		min := float64(minSingleGuesses)
+		if m.j > m.i {
+			min = minMultiGuesses
+		}
+		if m.guesses < min {
+			m.guesses = min
+		}
+	}
+	return matches
+}
+
+//userDictionary ranks the words of user inputs: emails are split in local-part and domain labels
+func userDictionary(inputs []string) map[string]int {
+	words := map[string]int{}
+	rank := 1
+	for _, input := range inputs {
+		for _, w := range strings.FieldsFunc(strings.ToLower(input), func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) }) {
+			if _, ok := words[w]; ok || utf8.RuneCountInString(w) < userInputMinRunes {
+				continue
+			}
+			words[w] = rank
+			rank++
+		}
+		//the whole local-part, with its separators
+		if i := strings.LastIndex(input, "@"); i >= userInputMinRunes {
+			if w := strings.ToLower(input[:i]); words[w] == 0 {
+				words[w] = rank
+				rank++
+			}
+		}
+	}
+	return words
+}
+
+//l33tTable lists the letters substituted by symbols
+var l33tTable = map[rune][]rune{
+	'4': {'a'}, '@': {'a'}, '8': {'b'}, '(': {'c'}, '{': {'c'}, '[': {'c'}, '<': {'c'}, '3': {'e'}, '6': {'g'}, '9': {'g'},
+	'1': {'i', 'l'}, '!': {'i'}, '|': {'i', 'l'}, '0': {'o'}, '$': {'s'}, '5': {'s'}, '+': {'t'}, '7': {'t', 'l'}, '%': {'x'}, '2': {'z'},
+}
+
+//dictionaryMatches finds common passwords and words, and user inputs, also reversed or with l33t substitutions
+func dictionaryMatches(runes, lower []rune, user map[string]int) []*match {
+	var matches []*match
+	lookup := func(word string) (string, int) {
+		if r, ok := user[word]; ok {
+			return patternUserInput, r
+		}
+		if r, ok := commonWords[word]; ok {
+			return patternDictionary, r
+		}
+		return "", 0
+	}
+	n := len(lower)
+	longest := longestWord(commonWords, user)
+	for i := 0; i < n; i++ {
+		//tokens longer than every word can not match
+		for j := i + 2; j < n && j-i < longest; j++ {
+			token := string(runes[i : j+1])
+			sub := lower[i : j+1]
+			if pattern, rank := lookup(string(sub)); rank > 0 {
+				matches = append(matches, newDictionaryMatch(pattern, i, j, token, rank, false, false))
+			}
+			if pattern, rank := lookup(reverse(sub)); rank > 0 {
+				matches = append(matches, newDictionaryMatch(pattern, i, j, token, rank, false, true))
+			}
+			for _, word := range unl33t(sub) {
+				if pattern, rank := lookup(word); rank > 0 {
+					matches = append(matches, newDictionaryMatch(pattern, i, j, token, rank, true, false))
+					break
+				}
+			}
+		}
+	}
+	return matches
+}
+
+//longestWord returns the length in runes of the longest word of the dictionaries
+func longestWord(dictionaries ...map[string]int) int {
+	longest := 0
+	for _, d := range dictionaries {
+		for w := range d {
+			if l := utf8.RuneCountInString(w); l > longest {
+				longest = l
+			}
+		}
+	}
+	return longest
+}
+
+func newDictionaryMatch(pattern string, i, j int, token string, rank int, l33t, reversed bool) *match {
+	m := &match{pattern: pattern, i: i, j: j, token: token, rank: rank, l33t: l33t, reverse: reversed}
+	m.guesses = float64(rank) * upperVariations(token)
+	m.upper = m.guesses > float64(rank)
+	if l33t {
+		m.guesses *= l33tVariations(token)
+	}
+	if reversed {
+		m.guesses *= 2
+	}
+	return m
+}
+
+//unl33t returns the words a token can stand for, replacing symbols by letters. Tokens without substitutions return nothing
+func unl33t(token []rune) []string {
+	words := []string{""}
+	substituted := false
+	for _, r := range token {
+		letters, ok := l33tTable[r]
+		if !ok {
+			letters = []rune{r}
+		} else {
+			substituted = true
+		}
+		var next []string
+		for _, w := range words {
+			for _, l := range letters {
+				next = append(next, w+string(l))
+			}
+		}
+		//bound the variants of tokens full of ambiguous symbols
+		if len(next) > 16 {
+			next = next[:16]
+		}
+		words = next
+	}
+	if !substituted {
+		return nil
+	}
+	return words
+}
+
+//upperVariations counts the capitalizations an attacker would try before token's (zxcvbn)
+func upperVariations(token string) float64 {
+	var upper, lower int
+	for _, r := range token {
+		switch {
+		case unicode.IsUpper(r):
+			upper++
+		case unicode.IsLower(r):
+			lower++
+		}
+	}
+	if upper == 0 {
+		return 1
+	}
+	runes := []rune(token)
+	first, last := unicode.IsUpper(runes[0]), unicode.IsUpper(runes[len(runes)-1])
+	if lower == 0 || (upper == 1 && (first || last)) {
+		return 2
+	}
+	var variations float64
+	for k := 1; k <= upper && k <= lower; k++ {
+		variations += nCk(upper+lower, k)
+	}
+	return variations
+}
+
+//l33tVariations counts the substitutions an attacker would try before token's
+func l33tVariations(token string) float64 {
+	subs := 0
+	for _, r := range token {
+		if _, ok := l33tTable[r]; ok {
+			subs++
+		}
+	}
+	return math.Max(2, math.Pow(2, float64(subs)))
+}
+
+func nCk(n, k int) float64 {
+	if k > n {
+		return 0
+	}
+	r := 1.0
+	for d := 1; d <= k; d++ {
+		r *= float64(n - k + d)
+		r /= float64(d)
+	}
+	return r
+}
+
+//keyboardLines are rows and columns of qwerty keyboards and keypads
+var keyboardLines = []string{
+	"`1234567890-=", "qwertyuiop[]\\", "asdfghjkl;'", "zxcvbnm,./",
+	"1qaz", "2wsx", "3edc", "4rfv", "5tgb", "6yhn", "7ujm", "8ik,", "9ol.", "0p;/",
+	"789", "456", "123", "147", "258", "369", "/*-",
+}
+
+//keyboardShifted maps shifted characters to their key
+var keyboardShifted = map[rune]rune{
+	'~': '`', '!': '1', '@': '2', '#': '3', '$': '4', '%': '5', '^': '6', '&': '7', '*': '8', '(': '9', ')': '0', '_': '-', '+': '=',
+	'{': '[', '}': ']', '|': '\\', ':': ';', '"': '\'', '<': ',', '>': '.', '?': '/',
+}
+
+//keyboardMatches finds runs of at least 3 adjacent keys
+func keyboardMatches(runes, lower []rune) []*match {
+	keys := make([]rune, len(lower))
+	shifted := make([]bool, len(lower))
+	for i, r := range lower {
+		keys[i] = r
+		if k, ok := keyboardShifted[r]; ok {
+			keys[i] = k
+			shifted[i] = true
+		} else if unicode.IsUpper(runes[i]) {
+			shifted[i] = true
+		}
+	}
+	var matches []*match
+	n := len(keys)
+	for i := 0; i < n; i++ {
+		for j := i + 2; j < n; j++ {
+			sub := string(keys[i : j+1])
+			if !onKeyboardLine(sub) {
+				break
+			}
+			m := &match{pattern: patternKeyboard, i: i, j: j, token: string(runes[i : j+1])}
+			m.guesses = float64(keyboardStarts * keyboardDegree * (j - i))
+			for _, s := range shifted[i : j+1] {
+				if s {
+					m.guesses *= 2
+					break
+				}
+			}
+			matches = append(matches, m)
+		}
+	}
+	return matches
+}
+
+func onKeyboardLine(s string) bool {
+	for _, line := range keyboardLines {
+		if strings.Contains(line, s) || strings.Contains(line, reverse([]rune(s))) {
+			return true
+		}
+	}
+	return false
+}
+
+//sequenceMatches finds runs of at least 3 characters with a constant step, like abc, 6543 or aceg
+func sequenceMatches(runes []rune) []*match {
+	var matches []*match
+	n := len(runes)
+	for i := 0; i+2 < n; {
+		delta := runes[i+1] - runes[i]
+		j := i + 1
+		for j+1 < n && runes[j+1]-runes[j] == delta {
+			j++
+		}
+		if j-i >= 2 && delta != 0 && delta >= -5 && delta <= 5 && sameClass(runes[i:j+1]) {
+			m := &match{pattern: patternSequence, i: i, j: j, token: string(runes[i : j+1])}
+			var base float64
+			switch first := runes[i]; {
+			case strings.ContainsRune("aAzZ019", first):
+				base = 4
+			case unicode.IsDigit(first):
+				base = 10
+			default:
+				base = 26
+			}
+			m.guesses = base * float64(j-i+1)
+			if delta < 0 {
+				m.guesses *= 2
+			}
+			matches = append(matches, m)
+			i = j
+			continue
+		}
+		i++
+	}
+	return matches
+}
+
+func sameClass(runes []rune) bool {
+	class := func(r rune) int {
+		switch {
+		case unicode.IsDigit(r):
+			return 1
+		case unicode.IsLower(r):
+			return 2
+		case unicode.IsUpper(r):
+			return 3
+		}
+		return 0
+	}
+	c := class(runes[0])
+	for _, r := range runes[1:] {
+		if class(r) != c {
+			return false
+		}
+	}
+	return c != 0
+}
+
+//repeatMatches finds characters repeated at least 3 times, and blocks repeated at least twice, like abcabc
+func repeatMatches(runes []rune, user map[string]int) []*match {
+	var matches []*match
+	n := len(runes)
+	for i := 0; i < n; i++ {
+		var best *match
+		for l := 1; i+2*l <= n; l++ {
+			block := string(runes[i : i+l])
+			k := 1
+			for i+(k+1)*l <= n && string(runes[i+k*l:i+(k+1)*l]) == block {
+				k++
+			}
+			if k < 2 || (l == 1 && k < 3) {
+				continue
+			}
+			if best != nil && best.j-best.i+1 >= k*l {
+				continue
+			}
+			guesses, _ := mostGuessable(runes[i:i+l], user)
+			best = &match{pattern: patternRepeat, i: i, j: i + k*l - 1, token: string(runes[i : i+k*l]), block: block, guesses: guesses * float64(k)}
+		}
+		if best != nil {
+			matches = append(matches, best)
+		}
+	}
+	return matches
+}
+
+//dateSeparators can separate the day, month and year of dates
+const dateSeparators = " /\\_.-"
+
+//dateMatches finds years and dates, with or without separators: 1987, 13.05.1987, 5/13/87, 19870513
+func dateMatches(runes []rune) []*match {
+	var matches []*match
+	now := time.Now().Year()
+	n := len(runes)
+	for i := 0; i < n; i++ {
+		for j := i + 3; j < n && j-i < 10; j++ {
+			token := string(runes[i : j+1])
+			year, sep, ok := parseDate(token)
+			if !ok {
+				continue
+			}
+			space := math.Max(math.Abs(float64(year-now)), minYearSpace)
+			m := &match{pattern: patternDate, i: i, j: j, token: token, guesses: space}
+			if len(token) > 4 {
+				m.guesses *= 365
+			}
+			if sep {
+				m.guesses *= 4
+			}
+			matches = append(matches, m)
+		}
+	}
+	return matches
+}
+
+//parseDate reads a year or a date of day, month and year in any usual order. It returns the year and whether separators were used
+func parseDate(token string) (int, bool, bool) {
+	if len(token) == 4 && allDigits(token) {
+		y := atoi(token)
+		return y, false, y >= 1900 && y <= 2099
+	}
+	var parts []string
+	sep := false
+	if allDigits(token) {
+		if len(token) > 8 {
+			return 0, false, false
+		}
+		for a := 1; a <= 4 && a < len(token); a++ {
+			for b := a + 1; b-a <= 4 && b < len(token); b++ {
+				if y, ok := validDate(token[:a], token[a:b], token[b:]); ok {
+					return y, false, true
+				}
+			}
+		}
+		return 0, false, false
+	}
+	for _, s := range dateSeparators {
+		if p := strings.Split(token, string(s)); len(p) == 3 {
+			parts = p
+			sep = true
+			break
+		}
+	}
+	if len(parts) != 3 {
+		return 0, false, false
+	}
+	y, ok := validDate(parts[0], parts[1], parts[2])
+	return y, sep, ok
+}
+
+//validDate checks if 3 numbers are a date: year-month-day, day-month-year or month-day-year
+func validDate(a, b, c string) (int, bool) {
+	for _, p := range []string{a, b, c} {
+		if len(p) == 0 || len(p) > 4 || !allDigits(p) {
+			return 0, false
+		}
+	}
+	year := func(s string) (int, bool) {
+		y := atoi(s)
+		switch len(s) {
+		case 2:
+			if y < 30 {
+				return 2000 + y, true
+			}
+			return 1900 + y, true
+		case 4:
+			return y, y >= 1900 && y <= 2099
+		}
+		return 0, false
+	}
+	dayMonth := func(d, m string) bool {
+		return len(d) <= 2 && len(m) <= 2 && atoi(d) >= 1 && atoi(d) <= 31 && atoi(m) >= 1 && atoi(m) <= 12
+	}
+	if y, ok := year(a); ok && dayMonth(c, b) {
+		return y, true
+	}
+	if y, ok := year(c); ok && (dayMonth(a, b) || dayMonth(b, a)) {
+		return y, true
+	}
+	return 0, false
+}
+
+func allDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
+
+func atoi(s string) int {
+	n := 0
+	for _, r := range s {
+		n = n*10 + int(r-'0')
+	}
+	return n
+}
+
+func reverse(runes []rune) string {
+	r := make([]rune, len(runes))
+	for i, c := range runes {
+		r[len(runes)-1-i] = c
+	}
+	return string(r)
+}
+
+//feedback explains the weakest pattern of passwords scoring 2 or less
+func (s *Strength) feedback(seq []*match, length int) {
+	if s.Score > 2 {
+		return
+	}
+	if length == 0 || len(seq) == 0 {
+		s.Suggestions = []string{"Use a few words, avoid common phrases", "No need for symbols, digits, or uppercase letters"}
+		return
+	}
+	longest := seq[0]
+	for _, m := range seq[1:] {
+		if m.j-m.i > longest.j-longest.i {
+			longest = m
+		}
+	}
+	s.Suggestions = []string{"Add another word or two. Uncommon words are better."}
+	switch longest.pattern {
+	case patternDictionary:
+		switch {
+		case longest.rank <= 10 && !longest.l33t && !longest.reverse && len(seq) == 1:
+			s.Warning = "This is a top-10 common password"
+		case longest.rank <= 100:
+			s.Warning = "This is a very common password"
+		default:
+			s.Warning = "This is similar to a commonly used password"
+		}
+		if longest.upper {
+			s.Suggestions = append(s.Suggestions, "Capitalization doesn't help very much")
+		}
+		if longest.reverse {
+			s.Suggestions = append(s.Suggestions, "Reversed words aren't much harder to guess")
+		}
+		if longest.l33t {
+			s.Suggestions = append(s.Suggestions, "Predictable substitutions like '@' instead of 'a' don't help very much")
+		}
+	case patternUserInput:
+		s.Warning = "Avoid using personal information like your email"
+	case patternKeyboard:
+		s.Warning = "Straight rows of keys are easy to guess"
+		s.Suggestions = append(s.Suggestions, "Use a longer keyboard pattern with more turns")
+	case patternSequence:
+		s.Warning = "Sequences like abc or 6543 are easy to guess"
+		s.Suggestions = append(s.Suggestions, "Avoid sequences")
+	case patternRepeat:
+		if utf8.RuneCountInString(longest.block) == 1 {
+			s.Warning = "Repeats like \"aaa\" are easy to guess"
+		} else {
+			s.Warning = "Repeats like \"abcabcabc\" are only slightly harder to guess than \"abc\""
+		}
+		s.Suggestions = append(s.Suggestions, "Avoid repeated words and characters")
+	case patternDate:
+		s.Warning = "Dates are often easy to guess"
+		s.Suggestions = append(s.Suggestions, "Avoid dates and years that are associated with you")
+	}
+}
@@ -0,0 +1,43 @@
+package passwords
+
+//commonWords ranks the most common passwords first, then common words and names. Ranks are the guesses needed to find them
+var commonWords = rankWords(
+	//passwords
+	"123456", "password", "123456789", "12345678", "12345", "qwerty", "1234567", "111111", "1234567890", "123123",
+	"abc123", "1234", "password1", "iloveyou", "1q2w3e4r", "000000", "qwerty123", "zaq12wsx", "dragon", "sunshine",
+	"princess", "letmein", "654321", "monkey", "27653", "1qaz2wsx", "123321", "qwertyuiop", "superman", "asdfghjkl",
+	"trustno1", "football", "baseball", "welcome", "admin", "login", "master", "hello", "freedom", "whatever",
+	"qazwsx", "shadow", "michael", "jennifer", "hunter", "batman", "starwars", "passw0rd", "access", "mustang",
+	"charlie", "donald", "secret", "ninja", "azerty", "solo", "loveme", "flower", "hottie", "jordan",
+	"harley", "ranger", "buster", "thomas", "tigger", "robert", "soccer", "killer", "hockey", "george",
+	"pepper", "daniel", "andrew", "maggie", "summer", "ashley", "cheese", "amanda", "nicole", "chelsea",
+	"biteme", "matthew", "yankees", "computer", "corvette", "austin", "thunder", "taylor", "matrix", "minecraft",
+	"merlin", "diamond", "ginger", "silver", "orange", "banana", "chocolate", "cookie", "pokemon", "naruto",
+	"samsung", "google", "internet", "changeme", "default", "guest", "root", "test", "demo", "user",
+	"qwe123", "asd123", "zxcvbnm", "asdf", "qwer", "pass", "passwd", "pwd", "abcd1234", "aa123456",
+	//words
+	"love", "life", "angel", "baby", "girl", "boy", "happy", "lucky", "magic", "money",
+	"power", "music", "friend", "family", "forever", "heart", "sweet", "honey", "sugar", "star",
+	"sun", "moon", "sky", "blue", "red", "green", "black", "white", "pink", "purple",
+	"spring", "autumn", "winter", "snow", "rain", "fire", "water", "earth", "tiger", "lion",
+	"eagle", "wolf", "bear", "horse", "kitty", "puppy", "dog", "cat", "fish", "bird",
+	"apple", "lemon", "cherry", "peach", "coffee", "pizza", "beer", "house", "home", "school",
+	"summer", "game", "player", "gamer", "king", "queen", "prince", "lady", "boss", "hero",
+	"devil", "god", "jesus", "christ", "peace", "dream", "hope", "faith", "trust", "smile",
+	"pretty", "cool", "crazy", "funny", "good", "best", "big", "little", "super", "mega",
+	"monday", "friday", "sunday", "january", "april", "june", "july", "august", "october", "december",
+	"welcome", "hello", "world", "change", "secure", "private", "company", "office", "work", "number",
+	//names
+	"john", "james", "david", "chris", "mike", "mark", "paul", "peter", "alex", "max",
+	"anna", "maria", "sarah", "jessica", "emma", "lisa", "julia", "laura", "sophie", "olivia",
+)
+
+func rankWords(words ...string) map[string]int {
+	ranks := make(map[string]int, len(words))
+	for i, w := range words {
+		if _, ok := ranks[w]; !ok {
+			ranks[w] = i + 1
+		}
+	}
+	return ranks
+}
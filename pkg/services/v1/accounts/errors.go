@@ -0,0 +1,44 @@
+package accounts
+
+import (
+	"github.com/klahssen/authn/pkg/jwt"
+	"github.com/klahssen/authn/pkg/log"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+//tokenError returns a grpc error for a token that failed validation. field is the name of the token in the request, details hold the reason
+func tokenError(field, msg string, err error) error {
+	code := codes.Unauthenticated
+	switch err {
+	case jwt.ErrMalformed:
+		code = codes.InvalidArgument
+	case jwt.ErrWrongAudience:
+		code = codes.PermissionDenied
+	}
+	st := status.New(code, msg)
+	v := &errdetails.BadRequest_FieldViolation{
+		Field:       field,
+		Description: err.Error(),
+	}
+	br := &errdetails.BadRequest{}
+	br.FieldViolations = append(br.FieldViolations, v)
+	st, err = st.WithDetails(br)
+	if err != nil {
+		// If this errored, it will always error
+		// here, so better panic so we can figure
+		// out why than have this silently passing.
+		log.Fatalf("Unexpected error attaching metadata: %v", err)
+	}
+	return st.Err()
+}
+
+//badRequest returns an InvalidArgument grpc error detailing the invalid fields
+func badRequest(msg string, violations ...*errdetails.BadRequest_FieldViolation) error {
+	st, err := status.New(codes.InvalidArgument, msg).WithDetails(&errdetails.BadRequest{FieldViolations: violations})
+	if err != nil {
+		log.Fatalf("Unexpected error attaching metadata: %v", err)
+	}
+	return st.Err()
+}
@@ -0,0 +1,128 @@
+package accounts
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	cotx "github.com/klahssen/authn/pkg/context"
+	"github.com/klahssen/authn/pkg/jwt"
+	"github.com/klahssen/authn/pkg/services/v1/actions"
+	pb "github.com/klahssen/authn/proto-gen/accounts/apiv1"
+	authz "github.com/klahssen/authn/proto-gen/authz/apiv1"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+//TokenTypeAccessToken is the type of the tokens issued by ExchangeToken (RFC 8693)
+const TokenTypeAccessToken = "urn:ietf:params:oauth:token-type:access_token"
+
+//ExchangeToken issues an access token for a calling service acting on behalf of the subject of a token (RFC 8693).
+//The new token is issued for other audiences, holds a subset of the roles and scopes of the subject token, names the calling service in its act claim and does not outlive the subject token.
+//DPoP-bound subject tokens can not be exchanged: the calling service does not hold their key
+func (s *Service) ExchangeToken(ctx context.Context, params *pb.TokenExchangeReq) (*pb.TokenExchangeResp, error) {
+	if params == nil || params.SubjectToken == "" {
+		return nil, status.Error(codes.InvalidArgument, "empty payload")
+	}
+	actorToken := params.ActorToken
+	if actorToken == "" {
+		actorToken = cotx.GetIdentityFromCtx(ctx).Token
+	}
+	actor, err := s.jwt.Access.Validate(actorToken)
+	if err != nil {
+		return nil, tokenError("actor_token", "invalid actor token", err)
+	}
+	subject, err := s.jwt.Access.Validate(params.SubjectToken)
+	if err != nil {
+		return nil, tokenError("subject_token", "invalid subject token", err)
+	}
+	if subject.Extra.Confirmation != nil {
+		return nil, badRequest("dpop-bound subject token", &errdetails.BadRequest_FieldViolation{
+			Field:       "subject_token",
+			Description: "dpop-bound tokens can not be exchanged",
+		})
+	}
+	authzParams := &authz.Req{
+		Identity:  &authz.Identity{Type: "jwt", Token: actorToken},
+		Action:    actions.AccountsExchangeToken,
+		Path:      []string{"accounts", subject.Custom.Uid},
+		Namespace: "",
+	}
+	resp, err := s.authz.Check(ctx, authzParams)
+	if err != nil {
+		return nil, err
+	}
+	if !resp.Authorized {
+		return nil, status.Error(codes.PermissionDenied, "permission denied")
+	}
+	if len(params.Audiences) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "audiences are empty")
+	}
+	if err = s.checkAudiences(params.Audiences); err != nil {
+		return nil, err
+	}
+	roles := subject.Custom.Roles
+	if len(params.Roles) > 0 {
+		roles = params.Roles
+	}
+	violations := notHeld("roles", roles, subject.Custom.Roles)
+	violations = append(violations, notHeld("scopes", params.Scopes, subject.Extra.Scopes)...)
+	if len(violations) > 0 {
+		return nil, badRequest("roles or scopes not held by the subject token", violations...)
+	}
+	scopes := jwt.Scopes(params.Scopes)
+	if len(scopes) == 0 {
+		scopes = subject.Extra.Scopes
+	}
+	custom := &pb.Info{Type: subject.Custom.Type, Uid: subject.Custom.Uid, Status: subject.Custom.Status, Roles: roles, MustChangePwd: subject.Custom.MustChangePwd}
+	extra := &jwt.ExtraClaims{
+		Tenant:      subject.Extra.Tenant,
+		SessionID:   subject.Extra.SessionID,
+		ClientID:    actor.Custom.Uid,
+		AuthMethods: subject.Extra.AuthMethods,
+		Scopes:      scopes,
+		Actor:       &jwt.Actor{Sub: actor.Custom.Uid, Act: subject.Extra.Actor},
+	}
+	now := s.jwt.Clock.Now()
+	exp := time.Unix(subject.Std.ExpiresAt, 0)
+	token, err := s.jwt.Access.GenerateWithParams(custom, now, &jwt.TokenParams{Audiences: params.Audiences, Extra: extra, NotAfter: exp})
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to generate access token")
+	}
+	expiresIn := now.Add(s.jwt.Access.Validity())
+	if exp.Before(expiresIn) {
+		expiresIn = exp
+	}
+	return &pb.TokenExchangeResp{
+		AccessToken:     token,
+		IssuedTokenType: TokenTypeAccessToken,
+		TokenType:       "Bearer",
+		ExpiresIn:       int64(expiresIn.Sub(now) / time.Second),
+		Scope:           strings.Join(scopes, " "),
+	}, nil
+}
+
+//notHeld returns a violation of field for each requested value that is not held
+func notHeld(field string, requested, held []string) []*errdetails.BadRequest_FieldViolation {
+	var violations []*errdetails.BadRequest_FieldViolation
+	for _, v := range requested {
+		if !contains(held, v) {
+			violations = append(violations, &errdetails.BadRequest_FieldViolation{
+				Field:       field,
+				Description: fmt.Sprintf("'%s' is not held by the subject token", v),
+			})
+		}
+	}
+	return violations
+}
+
+func contains(list []string, v string) bool {
+	for _, item := range list {
+		if item == v {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,127 @@
+package accounts
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/klahssen/authn/pkg/jwt"
+)
+
+var (
+	errUnknownRefreshToken = fmt.Errorf("unknown refresh token")
+	errRefreshTokenReused  = fmt.Errorf("refresh token reused: family revoked")
+	errFamilyRevoked       = fmt.Errorf("token family revoked")
+)
+
+//Family is a session: the refresh tokens issued since authentication, and the audiences of its access tokens
+type Family struct {
+	ID        string
+	Audiences []string
+}
+
+//FamilyStore tracks refresh token families: each refresh token can be used once, and reusing one revokes its whole family
+type FamilyStore interface {
+	//Issue records a new refresh token (id is its jti) in a family
+	Issue(ctx context.Context, family *Family, id string, exp time.Time) error
+	//Use marks a refresh token as used and returns its family. Reusing a token revokes the family and returns errRefreshTokenReused
+	Use(ctx context.Context, id string) (*Family, error)
+	//RevokeFamily invalidates every refresh token of a family
+	RevokeFamily(ctx context.Context, family string) error
+}
+
+type refreshEntry struct {
+	family *Family
+	used   bool
+	exp    time.Time
+}
+
+//MemFamilyStore is an in-memory FamilyStore
+type MemFamilyStore struct {
+	mu      sync.Mutex
+	tokens  map[string]*refreshEntry
+	revoked map[string]time.Time
+	clock   jwt.Clock
+}
+
+//NewMemFamilyStore returns an empty in-memory FamilyStore
+func NewMemFamilyStore() *MemFamilyStore {
+	return &MemFamilyStore{tokens: map[string]*refreshEntry{}, revoked: map[string]time.Time{}, clock: jwt.SystemClock}
+}
+
+//SetClock replaces the wall clock used to drop expired entries
+func (m *MemFamilyStore) SetClock(clock jwt.Clock) {
+	if clock == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.clock = clock
+}
+
+//Issue a refresh token in a family
+func (m *MemFamilyStore) Issue(ctx context.Context, family *Family, id string, exp time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.purge()
+	if _, ok := m.revoked[family.ID]; ok {
+		return errFamilyRevoked
+	}
+	f := &Family{ID: family.ID, Audiences: append([]string(nil), family.Audiences...)}
+	m.tokens[id] = &refreshEntry{family: f, exp: exp}
+	return nil
+}
+
+//Use a refresh token
+func (m *MemFamilyStore) Use(ctx context.Context, id string) (*Family, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.purge()
+	e, ok := m.tokens[id]
+	if !ok {
+		return nil, errUnknownRefreshToken
+	}
+	if _, ok = m.revoked[e.family.ID]; ok {
+		return e.family, errFamilyRevoked
+	}
+	if e.used {
+		m.revoke(e.family.ID)
+		return e.family, errRefreshTokenReused
+	}
+	e.used = true
+	return e.family, nil
+}
+
+//RevokeFamily invalidates every refresh token of a family
+func (m *MemFamilyStore) RevokeFamily(ctx context.Context, family string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.revoke(family)
+	return nil
+}
+
+func (m *MemFamilyStore) revoke(family string) {
+	var exp time.Time
+	for _, e := range m.tokens {
+		if e.family.ID == family && e.exp.After(exp) {
+			exp = e.exp
+		}
+	}
+	m.revoked[family] = exp
+}
+
+//purge forgets expired tokens, and revoked families whose tokens all expired
+func (m *MemFamilyStore) purge() {
+	now := m.clock.Now()
+	for id, e := range m.tokens {
+		if !now.Before(e.exp) {
+			delete(m.tokens, id)
+		}
+	}
+	for family, exp := range m.revoked {
+		if !now.Before(exp) {
+			delete(m.revoked, family)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package accounts
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/klahssen/authn/pkg/jwt"
+	"github.com/klahssen/authn/pkg/passwords"
+	pb "github.com/klahssen/authn/proto-gen/accounts/apiv1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+//IntrospectionPath is the path of the HTTP introspection endpoint
+const IntrospectionPath = "/oauth2/introspect"
+
+//ClientAuthFunc authenticates a confidential client from its credentials
+type ClientAuthFunc func(ctx context.Context, clientID, secret string) bool
+
+//StaticClients returns a ClientAuthFunc checking credentials against a map of client IDs to hashed secrets (see passwords.HashAndSalt)
+func StaticClients(hashes map[string]string) ClientAuthFunc {
+	return func(ctx context.Context, clientID, secret string) bool {
+		hash, ok := hashes[clientID]
+		if !ok || clientID == "" {
+			return false
+		}
+		return passwords.CompareHashAndPassword(hash, []byte(secret))
+	}
+}
+
+//SetClientAuthFunc sets the authentication of confidential clients allowed to introspect tokens, and of the clients named in the tokens issued by Authn. Introspect rejects every caller if it is not set
+func (s *Service) SetClientAuthFunc(fn ClientAuthFunc) {
+	s.clientAuth = fn
+}
+
+//authenticatedClient returns clientID if the client authenticates with secret, an empty string otherwise: tokens only name authenticated clients
+func (s *Service) authenticatedClient(ctx context.Context, clientID, secret string) string {
+	if clientID == "" || s.clientAuth == nil || !s.clientAuth(ctx, clientID, secret) {
+		return ""
+	}
+	return clientID
+}
+
+//Introspect returns the state of a token (RFC 7662). Invalid, expired or revoked tokens are inactive
+func (s *Service) Introspect(ctx context.Context, params *pb.IntrospectionReq) (*pb.IntrospectionResp, error) {
+	if params == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty payload")
+	}
+	if s.clientAuth == nil || !s.clientAuth(ctx, params.ClientId, params.ClientSecret) {
+		return nil, status.Error(codes.Unauthenticated, "invalid client credentials")
+	}
+	if params.Token == "" {
+		return nil, status.Error(codes.InvalidArgument, "token is empty")
+	}
+	handlers := []struct {
+		tokenType string
+		h         jwt.Handler
+	}{
+		{"access_token", s.jwt.Access},
+		{"refresh_token", s.jwt.Refresh},
+	}
+	if params.TokenTypeHint == "refresh_token" {
+		handlers[0], handlers[1] = handlers[1], handlers[0]
+	}
+	for _, h := range handlers {
+		claims, err := h.h.Validate(params.Token)
+		if err != nil {
+			continue
+		}
+		return &pb.IntrospectionResp{
+			Active:        true,
+			Sub:           claims.Custom.Uid,
+			Exp:           claims.Std.ExpiresAt,
+			Iat:           int64(claims.Std.IssuedAt),
+			Nbf:           claims.Std.NotBefore,
+			Iss:           claims.Std.Issuer,
+			Aud:           []string(claims.Std.Audience),
+			Scope:         strings.Join(claims.Extra.Scopes, " "),
+			ClientId:      claims.Extra.ClientID,
+			Jti:           claims.Std.Id,
+			TokenType:     h.tokenType,
+			Type:          claims.Custom.Type,
+			Status:        claims.Custom.Status,
+			Roles:         claims.Custom.Roles,
+			MustChangePwd: claims.Custom.MustChangePwd,
+		}, nil
+	}
+	return &pb.IntrospectionResp{Active: false}, nil
+}
+
+//IntrospectionHandler serves Introspect over HTTP (RFC 7662): a form encoded POST with the token, the client authenticating with HTTP Basic or client_id/client_secret form parameters
+func (s *Service) IntrospectionHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			writeOAuthError(w, http.StatusMethodNotAllowed, "invalid_request")
+			return
+		}
+		if err := r.ParseForm(); err != nil {
+			writeOAuthError(w, http.StatusBadRequest, "invalid_request")
+			return
+		}
+		params := &pb.IntrospectionReq{Token: r.PostForm.Get("token"), TokenTypeHint: r.PostForm.Get("token_type_hint")}
+		if id, secret, ok := r.BasicAuth(); ok {
+			params.ClientId, params.ClientSecret = id, secret
+		} else {
+			params.ClientId, params.ClientSecret = r.PostForm.Get("client_id"), r.PostForm.Get("client_secret")
+		}
+		resp, err := s.Introspect(r.Context(), params)
+		if err != nil {
+			switch status.Code(err) {
+			case codes.Unauthenticated:
+				w.Header().Set("WWW-Authenticate", `Basic realm="introspection"`)
+				writeOAuthError(w, http.StatusUnauthorized, "invalid_client")
+			case codes.InvalidArgument:
+				writeOAuthError(w, http.StatusBadRequest, "invalid_request")
+			default:
+				writeOAuthError(w, http.StatusInternalServerError, "server_error")
+			}
+			return
+		}
+		w.Header().Set("Cache-Control", "no-store")
+		if !resp.Active {
+			writeJSON(w, http.StatusOK, map[string]bool{"active": false})
+			return
+		}
+		writeJSON(w, http.StatusOK, resp)
+	})
+}
+
+func writeOAuthError(w http.ResponseWriter, code int, errCode string) {
+	writeJSON(w, code, map[string]string{"error": errCode})
+}
+
+func writeJSON(w http.ResponseWriter, code int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,163 @@
+package accounts
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/klahssen/authn/pkg/jwt"
+	"github.com/klahssen/authn/pkg/services/v1/actions"
+	authz "github.com/klahssen/authn/proto-gen/authz/apiv1"
+	"golang.org/x/crypto/ed25519"
+)
+
+//KeyRingPath is the path of the HTTP key rotation endpoint
+const KeyRingPath = "/admin/keys"
+
+//StageKeyReq stages a new key generated by the service: its private key never leaves the ring
+type StageKeyReq struct {
+	Kid string `json:"kid"`
+	//Alg of the key: RS256, ES256 or EdDSA
+	Alg string `json:"alg"`
+	//ActivatesAt is when the key starts signing (now if empty)
+	ActivatesAt *time.Time `json:"activates_at,omitempty"`
+}
+
+//KeyInfo describes a key of the ring, without its private part
+type KeyInfo struct {
+	Kid         string     `json:"kid"`
+	Alg         string     `json:"alg"`
+	ActivatesAt time.Time  `json:"activates_at"`
+	RetiresAt   *time.Time `json:"retires_at,omitempty"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"`
+}
+
+//KeyRingHandler lets administrators rotate the keys of kr at runtime. Requests are authorized like RPCs, with the bearer token of their Authorization header:
+//	GET    KeyRingPath                 lists the keys (actions.KeysList)
+//	POST   KeyRingPath                 stages a key generated from a StageKeyReq (actions.KeysStage)
+//	POST   KeyRingPath/<kid>/promote   activates a staged key now (actions.KeysPromote)
+//	POST   KeyRingPath/<kid>/retire    stops signing with a key now (actions.KeysRetire)
+func (s *Service) KeyRingHandler(kr *jwt.KeyRing) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		parts := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, KeyRingPath), "/"), "/")
+		var action, kid string
+		switch {
+		case len(parts) == 1 && parts[0] == "" && r.Method == http.MethodGet:
+			action = actions.KeysList
+		case len(parts) == 1 && parts[0] == "" && r.Method == http.MethodPost:
+			action = actions.KeysStage
+		case len(parts) == 2 && parts[1] == "promote" && r.Method == http.MethodPost:
+			action, kid = actions.KeysPromote, parts[0]
+		case len(parts) == 2 && parts[1] == "retire" && r.Method == http.MethodPost:
+			action, kid = actions.KeysRetire, parts[0]
+		default:
+			writeJSON(w, http.StatusNotFound, map[string]string{"error": "not found"})
+			return
+		}
+		var params StageKeyReq
+		if action == actions.KeysStage {
+			if err := json.NewDecoder(r.Body).Decode(&params); err != nil || params.Kid == "" {
+				writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid request"})
+				return
+			}
+			kid = params.Kid
+		}
+		if code := s.authorizeKeys(r, action, kid); code != http.StatusOK {
+			writeJSON(w, code, map[string]string{"error": http.StatusText(code)})
+			return
+		}
+		var err error
+		switch action {
+		case actions.KeysList:
+			writeJSON(w, http.StatusOK, keyInfos(kr.Keys()))
+			return
+		case actions.KeysStage:
+			err = stageKey(kr, &params)
+		case actions.KeysPromote:
+			err = kr.Promote(kid)
+		case actions.KeysRetire:
+			err = kr.Retire(kid)
+		}
+		switch err {
+		case nil:
+			writeJSON(w, http.StatusOK, keyInfos(kr.Keys()))
+		case jwt.ErrUnknownKey:
+			writeJSON(w, http.StatusNotFound, map[string]string{"error": err.Error()})
+		default:
+			writeJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+		}
+	})
+}
+
+//authorizeKeys checks the bearer token of r for an action on a key. It returns an HTTP status
+func (s *Service) authorizeKeys(r *http.Request, action, kid string) int {
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if token == "" || token == r.Header.Get("Authorization") {
+		return http.StatusUnauthorized
+	}
+	path := []string{"keys"}
+	if kid != "" {
+		path = append(path, kid)
+	}
+	resp, err := s.authz.Check(r.Context(), &authz.Req{
+		Identity:  &authz.Identity{Type: "jwt", Token: token},
+		Action:    action,
+		Path:      path,
+		Namespace: "",
+	})
+	if err != nil || !resp.Authorized {
+		return http.StatusForbidden
+	}
+	return http.StatusOK
+}
+
+//stageKey generates a key for params.Alg and stages it
+func stageKey(kr *jwt.KeyRing, params *StageKeyReq) error {
+	var key crypto.Signer
+	var err error
+	switch params.Alg {
+	case "RS256":
+		key, err = rsa.GenerateKey(rand.Reader, 2048)
+	case "ES256":
+		key, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	case "EdDSA":
+		_, key, err = ed25519.GenerateKey(rand.Reader)
+	default:
+		return fmt.Errorf("unsupported alg '%s'", params.Alg)
+	}
+	if err != nil {
+		return err
+	}
+	var activatesAt time.Time
+	if params.ActivatesAt != nil {
+		activatesAt = *params.ActivatesAt
+	}
+	return kr.Stage(params.Kid, key, activatesAt)
+}
+
+func keyInfos(keys []jwt.RingKey) []*KeyInfo {
+	res := make([]*KeyInfo, 0, len(keys))
+	for _, k := range keys {
+		info := &KeyInfo{Kid: k.Kid, ActivatesAt: k.ActivatesAt}
+		if j, err := jwt.NewJWK(k.Kid, k.Key.Public()); err == nil {
+			info.Alg = j.Alg
+		}
+		if !k.RetiresAt.IsZero() {
+			retiresAt := k.RetiresAt
+			info.RetiresAt = &retiresAt
+		}
+		if !k.ExpiresAt.IsZero() {
+			expiresAt := k.ExpiresAt
+			info.ExpiresAt = &expiresAt
+		}
+		res = append(res, info)
+	}
+	return res
+}
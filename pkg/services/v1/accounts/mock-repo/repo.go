@@ -44,6 +44,7 @@ func (r *Repo) Insert(ctx context.Context, params *pb.Account) (*pb.AccountID, e
 	if params == nil {
 		return nil, status.Error(codes.InvalidArgument, "empty payload")
 	}
+	params.Uid = params.Email
 	r.data[params.Email] = params
 	return &pb.AccountID{Id: params.Email, Type: pb.IDType_UID}, nil
 }
@@ -62,8 +63,13 @@ func (r *Repo) Get(ctx context.Context, params *pb.AccountID) (*pb.Account, erro
 	if params == nil {
 		return nil, status.Error(codes.InvalidArgument, "empty payload")
 	}
-	if params.Type != pb.IDType_UID {
-		return nil, status.Error(codes.InvalidArgument, "can only get by Uid")
+	if params.Type == pb.IDType_EMAIL {
+		for _, acc := range r.data {
+			if acc.Email == params.Id {
+				return acc, nil
+			}
+		}
+		return nil, status.Error(codes.NotFound, fmt.Sprintf("account '%s' not found", params.Id))
 	}
 	acc, ok := r.data[params.Id]
 	if !ok {
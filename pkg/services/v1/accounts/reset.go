@@ -0,0 +1,325 @@
+package accounts
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/klahssen/authn/pkg/jwt"
+	"github.com/klahssen/authn/pkg/log"
+	"github.com/klahssen/authn/pkg/notify"
+	pb "github.com/klahssen/authn/proto-gen/accounts/apiv1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+//DefaultResetTokenValidity is the validity of password reset tokens if PasswordReset.Validity is not set
+const DefaultResetTokenValidity = time.Minute * 15
+
+//Defaults of the number of workers sending reset links and of the pending requests they hold, if PasswordReset.Workers and PasswordReset.Queue are not set
+const (
+	DefaultResetWorkers = 4
+	DefaultResetQueue   = 256
+)
+
+const opaqueTokenSize = 32
+
+var (
+	errUnknownResetToken = fmt.Errorf("unknown reset token")
+	errNoResetNotifier   = fmt.Errorf("password reset notifier is nil")
+	errInvalidResetURL   = fmt.Errorf("invalid password reset url")
+	errMissingUID        = fmt.Errorf("account read by email has no uid")
+)
+
+//ResetTokenStore holds password reset tokens, by hash: tokens are not kept in clear
+type ResetTokenStore interface {
+	//Save the hash of a token issued to an account until exp
+	Save(ctx context.Context, hash, uid string, exp time.Time) error
+	//Lookup returns the account of a valid token
+	Lookup(ctx context.Context, hash string) (string, error)
+	//Use consumes a token. Used and expired tokens return errUnknownResetToken
+	Use(ctx context.Context, hash string) error
+}
+
+type resetEntry struct {
+	uid string
+	exp time.Time
+}
+
+//MemResetTokenStore is an in-memory ResetTokenStore
+type MemResetTokenStore struct {
+	mu     sync.Mutex
+	tokens map[string]*resetEntry
+	clock  jwt.Clock
+}
+
+//NewMemResetTokenStore returns an empty in-memory ResetTokenStore
+func NewMemResetTokenStore() *MemResetTokenStore {
+	return &MemResetTokenStore{tokens: map[string]*resetEntry{}, clock: jwt.SystemClock}
+}
+
+//SetClock replaces the wall clock used to expire tokens
+func (m *MemResetTokenStore) SetClock(clock jwt.Clock) {
+	if clock == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.clock = clock
+}
+
+//Save a token hash
+func (m *MemResetTokenStore) Save(ctx context.Context, hash, uid string, exp time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.purge()
+	m.tokens[hash] = &resetEntry{uid: uid, exp: exp}
+	return nil
+}
+
+//Lookup a token hash
+func (m *MemResetTokenStore) Lookup(ctx context.Context, hash string) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.purge()
+	e, ok := m.tokens[hash]
+	if !ok {
+		return "", errUnknownResetToken
+	}
+	return e.uid, nil
+}
+
+//Use a token hash
+func (m *MemResetTokenStore) Use(ctx context.Context, hash string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.purge()
+	if _, ok := m.tokens[hash]; !ok {
+		return errUnknownResetToken
+	}
+	delete(m.tokens, hash)
+	return nil
+}
+
+func (m *MemResetTokenStore) purge() {
+	now := m.clock.Now()
+	for hash, e := range m.tokens {
+		if !now.Before(e.exp) {
+			delete(m.tokens, hash)
+		}
+	}
+}
+
+//PasswordReset configures the self-service password reset
+type PasswordReset struct {
+	//Notifier sends reset links to users
+	Notifier notify.Notifier
+	//Tokens holds reset tokens (defaults to an in-memory store)
+	Tokens ResetTokenStore
+	//URL is the page completing resets: the token is added as its "token" query parameter
+	URL string
+	//Validity of reset tokens (defaults to DefaultResetTokenValidity)
+	Validity time.Duration
+	//Workers sending reset links after responding (defaults to DefaultResetWorkers)
+	Workers int
+	//Queue is the number of pending requests: requests are dropped when it is full (defaults to DefaultResetQueue)
+	Queue int
+}
+
+//resetQueue sends reset links after responding, with a bounded number of workers and pending requests
+type resetQueue struct {
+	mu      sync.Mutex
+	emails  chan string
+	closed  bool
+	pending sync.WaitGroup
+	workers sync.WaitGroup
+}
+
+func newResetQueue(workers, size int, send func(email string)) *resetQueue {
+	q := &resetQueue{emails: make(chan string, size)}
+	q.workers.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer q.workers.Done()
+			for email := range q.emails {
+				send(email)
+				q.pending.Done()
+			}
+		}()
+	}
+	return q
+}
+
+//push queues a request. It returns false if the queue is full or closed
+func (q *resetQueue) push(email string) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.closed {
+		return false
+	}
+	q.pending.Add(1)
+	select {
+	case q.emails <- email:
+		return true
+	default:
+		q.pending.Done()
+		return false
+	}
+}
+
+//flush waits for the pending requests
+func (q *resetQueue) flush() {
+	q.pending.Wait()
+}
+
+//close stops accepting requests and waits for the pending ones
+func (q *resetQueue) close() {
+	q.mu.Lock()
+	if !q.closed {
+		q.closed = true
+		close(q.emails)
+	}
+	q.mu.Unlock()
+	q.workers.Wait()
+}
+
+//SetPasswordReset enables the password reset RPCs. They return Unimplemented errors if it is not set
+func (s *Service) SetPasswordReset(cfg *PasswordReset) error {
+	if cfg == nil || cfg.Notifier == nil {
+		return errNoResetNotifier
+	}
+	if _, err := url.Parse(cfg.URL); err != nil || cfg.URL == "" {
+		return errInvalidResetURL
+	}
+	reset := *cfg
+	if reset.Tokens == nil {
+		reset.Tokens = NewMemResetTokenStore()
+	}
+	if reset.Validity <= 0 {
+		reset.Validity = DefaultResetTokenValidity
+	}
+	if reset.Workers <= 0 {
+		reset.Workers = DefaultResetWorkers
+	}
+	if reset.Queue <= 0 {
+		reset.Queue = DefaultResetQueue
+	}
+	if c, ok := reset.Tokens.(jwt.Clocked); ok {
+		c.SetClock(s.jwt.Clock)
+	}
+	if s.resets != nil {
+		s.resets.close()
+	}
+	s.reset = &reset
+	s.resets = newResetQueue(reset.Workers, reset.Queue, func(email string) {
+		if err := s.sendResetLink(context.Background(), email); err != nil {
+			log.Warnf("failed to send password reset link: %v", err)
+		}
+	})
+	return nil
+}
+
+//RequestPasswordReset sends a single-use reset link to the email of an account. The response is the same whether the account exists or not:
+//the account is looked up and the link sent after responding by the workers of the service, so that the response time does not tell either. Requests are dropped when too many are pending
+func (s *Service) RequestPasswordReset(ctx context.Context, params *pb.PasswordResetReq) (*pb.AccountID, error) {
+	if params == nil || params.Email == "" {
+		return nil, status.Error(codes.InvalidArgument, "empty payload")
+	}
+	if s.reset == nil {
+		return nil, status.Error(codes.Unimplemented, "password reset is not enabled")
+	}
+	if !s.resets.push(params.Email) {
+		log.Warnf("password reset request dropped: too many pending requests")
+	}
+	return &pb.AccountID{}, nil
+}
+
+//sendResetLink issues a reset token to the account of email, if any, and sends it. Errors are not returned to clients
+func (s *Service) sendResetLink(ctx context.Context, email string) error {
+	token, hash, err := newOpaqueToken()
+	if err != nil {
+		return err
+	}
+	a, err := s.datastore.Get(ctx, &pb.AccountID{Id: email, Type: pb.IDType_EMAIL})
+	if err != nil || a == nil || a.Status == pb.AccountStatus_DELETED {
+		return nil
+	}
+	if a.Uid == "" {
+		return errMissingUID
+	}
+	if err = s.reset.Tokens.Save(ctx, hash, a.Uid, s.jwt.Clock.Now().Add(s.reset.Validity)); err != nil {
+		return err
+	}
+	link, err := url.Parse(s.reset.URL)
+	if err != nil {
+		return err
+	}
+	q := link.Query()
+	q.Set("token", token)
+	link.RawQuery = q.Encode()
+	return s.reset.Notifier.Notify(ctx, &notify.Message{
+		To:      a.Email,
+		Subject: "Reset your password",
+		Body:    fmt.Sprintf("Follow this link within %s to choose a new password: %s\nIgnore this message if you did not ask to reset your password.", s.reset.Validity, link),
+		Link:    link.String(),
+	})
+}
+
+//CompletePasswordReset sets the password of the account of a reset token and revokes its sessions. The token can not be used again
+func (s *Service) CompletePasswordReset(ctx context.Context, params *pb.CompletePasswordResetReq) (*pb.AccountID, error) {
+	if params == nil || params.Token == "" {
+		return nil, status.Error(codes.InvalidArgument, "empty payload")
+	}
+	if s.reset == nil {
+		return nil, status.Error(codes.Unimplemented, "password reset is not enabled")
+	}
+	hash := hashOpaqueToken(params.Token)
+	invalid := status.Error(codes.InvalidArgument, "invalid reset token")
+	uid, err := s.reset.Tokens.Lookup(ctx, hash)
+	if err != nil {
+		return nil, invalid
+	}
+	a, err := s.getAccount(ctx, &pb.AccountID{Id: uid, Type: pb.IDType_UID})
+	if err != nil {
+		return nil, invalid
+	}
+	//the token stays valid if the new password is rejected
+	updated := *a
+	if err = s.validator.UpdatePwd(&updated, params.Pwd); err != nil {
+		return nil, err
+	}
+	if err = s.reset.Tokens.Use(ctx, hash); err != nil {
+		return nil, invalid
+	}
+	updated.UpdatedAt = time.Now().Unix()
+	res, err := s.datastore.Update(ctx, &pb.PutAccountParams{Uid: uid, Acct: &updated})
+	if err != nil {
+		return nil, err
+	}
+	if err = s.revokeSessions(uid); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+//newOpaqueToken returns a random token, for reset links and email verification, and its hash
+func newOpaqueToken() (string, string, error) {
+	b := make([]byte, opaqueTokenSize)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", err
+	}
+	token := base64.RawURLEncoding.EncodeToString(b)
+	return token, hashOpaqueToken(token), nil
+}
+
+//hashOpaqueToken returns the hash of a token kept at rest. Tokens are random: a fast hash is enough
+func hashOpaqueToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
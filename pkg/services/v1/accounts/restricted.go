@@ -0,0 +1,42 @@
+package accounts
+
+import (
+	"context"
+
+	"github.com/klahssen/authn/pkg/jwt"
+	"github.com/klahssen/authn/pkg/services/v1/actions"
+	pb "github.com/klahssen/authn/proto-gen/accounts/apiv1"
+	authz "github.com/klahssen/authn/proto-gen/authz/apiv1"
+)
+
+//restrictedAuthz enforces the restrictions of access tokens before asking the authz service: tokens of accounts whose password expired only allow UpdatePassword on their own account, tokens of INACTIVE accounts only allow Reactivate on their own account
+type restrictedAuthz struct {
+	next   authz.AuthzAPIServer
+	access jwt.Handler
+}
+
+//Check denies the requests of restricted tokens, other requests are checked by the authz service
+func (r *restrictedAuthz) Check(ctx context.Context, params *authz.Req) (*authz.Resp, error) {
+	if r.access != nil && params != nil && params.Identity != nil && params.Identity.Token != "" {
+		claims, err := r.access.Validate(params.Identity.Token)
+		if err == nil && claims.Custom.MustChangePwd && !allowedToChangePwd(claims, params) {
+			return &authz.Resp{Authorized: false}, nil
+		}
+		if err == nil && claims.Custom.Status == pb.AccountStatus_INACTIVE && !allowedToReactivate(claims, params) {
+			return &authz.Resp{Authorized: false}, nil
+		}
+	}
+	return r.next.Check(ctx, params)
+}
+
+func allowedToChangePwd(claims *jwt.AccessToken, params *authz.Req) bool {
+	return params.Action == actions.AccountsUpdatePassword && ownAccount(claims, params)
+}
+
+func allowedToReactivate(claims *jwt.AccessToken, params *authz.Req) bool {
+	return params.Action == actions.AccountsReactivate && ownAccount(claims, params)
+}
+
+func ownAccount(claims *jwt.AccessToken, params *authz.Req) bool {
+	return len(params.Path) == 2 && params.Path[0] == "accounts" && params.Path[1] == claims.Custom.Uid
+}
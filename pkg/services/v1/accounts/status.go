@@ -0,0 +1,68 @@
+package accounts
+
+import (
+	"context"
+	"time"
+
+	cotx "github.com/klahssen/authn/pkg/context"
+	"github.com/klahssen/authn/pkg/jwt"
+	"github.com/klahssen/authn/pkg/services/v1/actions"
+	pb "github.com/klahssen/authn/proto-gen/accounts/apiv1"
+	authz "github.com/klahssen/authn/proto-gen/authz/apiv1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+//ScopeReactivate is the only scope of the access tokens issued to INACTIVE accounts
+const ScopeReactivate = "accounts.reactivate"
+
+//Reactivate moves an INACTIVE account back to ACTIVE. It is the only call allowed to the tokens issued to INACTIVE accounts
+func (s *Service) Reactivate(ctx context.Context, params *pb.AccountID) (*pb.AccountID, error) {
+	if params == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty payload")
+	}
+	authzParams := &authz.Req{
+		Identity:  cotx.GetIdentityFromCtx(ctx),
+		Action:    actions.AccountsReactivate,
+		Path:      []string{"accounts", params.Id},
+		Namespace: "",
+	}
+	resp, err := s.authz.Check(ctx, authzParams)
+	if err != nil {
+		return nil, err
+	}
+	if !resp.Authorized {
+		return nil, status.Error(codes.PermissionDenied, "permission denied")
+	}
+	a, err := s.getAccount(ctx, &pb.AccountID{Id: params.Id, Type: pb.IDType_UID})
+	if err != nil {
+		return nil, err
+	}
+	//LOCKED and CREATED accounts can become ACTIVE too, but not on their own
+	if a.Status != pb.AccountStatus_INACTIVE {
+		return nil, status.Error(codes.FailedPrecondition, "account is not inactive")
+	}
+	if err = s.validator.UpdateStatus(a, pb.AccountStatus_ACTIVE); err != nil {
+		return nil, err
+	}
+	a.UpdatedAt = time.Now().Unix()
+	return s.datastore.Update(ctx, &pb.PutAccountParams{Uid: params.Id, Acct: a})
+}
+
+//checkLogin refuses the login of LOCKED and DELETED accounts. DELETED accounts are reported as unknown
+func checkLogin(a *pb.Account) error {
+	switch a.Status {
+	case pb.AccountStatus_LOCKED:
+		return status.Error(codes.PermissionDenied, "account locked")
+	case pb.AccountStatus_DELETED:
+		return status.Error(codes.Unauthenticated, "incorrect credentials")
+	}
+	return nil
+}
+
+//issueReactivationToken returns an access token of an INACTIVE account only allowing to reactivate it, without refresh token
+func (s *Service) issueReactivationToken(custom *pb.Info, extra *jwt.ExtraClaims) (*pb.JwtAuthTokens, error) {
+	scoped := *extra
+	scoped.Scopes = jwt.Scopes{ScopeReactivate}
+	return s.issueAccessToken(custom, &scoped)
+}
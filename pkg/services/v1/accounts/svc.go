@@ -2,36 +2,58 @@ package accounts
 
 import (
 	"context"
+	"fmt"
+	"net/http"
 	"time"
 
 	cotx "github.com/klahssen/authn/pkg/context"
 	"github.com/klahssen/authn/pkg/jwt"
+	"github.com/klahssen/authn/pkg/log"
 	"github.com/klahssen/authn/pkg/services/v1/actions"
 	pb "github.com/klahssen/authn/proto-gen/accounts/apiv1"
 	authz "github.com/klahssen/authn/proto-gen/authz/apiv1"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
 type Service struct {
-	datastore pb.AccountRepoServer
-	authz     authz.AuthzAPIServer
-	jwt       *TokensHandler
-	validator *pb.AccountValidator
+	datastore    pb.AccountRepoServer
+	authz        authz.AuthzAPIServer
+	jwt          *TokensHandler
+	validator    *pb.AccountValidator
+	clientAuth   ClientAuthFunc
+	reset        *PasswordReset
+	verification *EmailVerification
+	//resets sends reset links after responding
+	resets *resetQueue
 }
 
 //TokensHandler holds a handler for each type of token (Access and Refresh)
 type TokensHandler struct {
-	Access  jwt.Handler
+	Access jwt.Handler
+	//Refresh can be a jwt.JWEHandler so that refresh tokens are opaque to clients
 	Refresh jwt.Handler
+	//Families tracks refresh token families (defaults to an in-memory store)
+	Families FamilyStore
+	//Revoked holds revoked tokens (defaults to an in-memory store). It is set on Access and Refresh handlers implementing jwt.Revocable
+	Revoked jwt.RevocationStore
+	//Clock is used to issue and revoke tokens (defaults to the wall clock). It is set on handlers and stores implementing jwt.Clocked
+	Clock jwt.Clock
+	//Audiences are the downstream services clients can ask access tokens for. They are accepted by an Access handler implementing jwt.AudienceAcceptor
+	Audiences []string
+	//DPoP verifies the proofs sent to Authn and Refresh. Tokens are bound to the key of the proof (RFC 9449). Clients can not send proofs if it is not set
+	DPoP *jwt.DPoPVerifier
+	//TokenURI is the URI (htu claim) of the proofs sent to Authn and Refresh
+	TokenURI string
 }
 
 //func New(datastore pb.AccountRepoServer) (pb.AccountsAPIServer, error) {
-func New(datastore pb.AccountRepoServer, authz authz.AuthzAPIServer, validator *pb.AccountValidator, jwt *TokensHandler) (*Service, error) {
+func New(datastore pb.AccountRepoServer, authz authz.AuthzAPIServer, validator *pb.AccountValidator, tokens *TokensHandler) (*Service, error) {
 	if datastore == nil {
 		return nil, status.Error(codes.Internal, "datastore is nil")
 	}
-	if jwt == nil {
+	if tokens == nil {
 		return nil, status.Error(codes.Internal, "jwt handler is nil")
 	}
 	if validator == nil {
@@ -40,7 +62,40 @@ func New(datastore pb.AccountRepoServer, authz authz.AuthzAPIServer, validator *
 	if authz == nil {
 		return nil, status.Error(codes.Internal, "authz is nil")
 	}
-	return &Service{datastore: datastore, jwt: jwt, authz: authz, validator: validator}, nil
+	th := *tokens
+	if th.Families == nil {
+		th.Families = NewMemFamilyStore()
+	}
+	if th.Revoked == nil {
+		th.Revoked = jwt.NewMemRevocationStore()
+	}
+	if th.Clock == nil {
+		th.Clock = jwt.SystemClock
+	}
+	for _, h := range []jwt.Handler{th.Access, th.Refresh} {
+		if r, ok := h.(jwt.Revocable); ok {
+			r.SetRevocationStore(th.Revoked)
+		}
+	}
+	if a, ok := th.Access.(jwt.AudienceAcceptor); ok {
+		a.AcceptAudiences(th.Audiences...)
+	}
+	for _, c := range []interface{}{th.Access, th.Refresh, th.Families, th.Revoked} {
+		if c, ok := c.(jwt.Clocked); ok {
+			c.SetClock(th.Clock)
+		}
+	}
+	if th.DPoP != nil {
+		th.DPoP.SetClock(th.Clock)
+	}
+	return &Service{datastore: datastore, jwt: &th, authz: &restrictedAuthz{next: authz, access: th.Access}, validator: validator}, nil
+}
+
+//Close stops the work the service does after responding: it returns once the pending password reset links are sent. Call it on shutdown
+func (s *Service) Close() {
+	if s.resets != nil {
+		s.resets.close()
+	}
 }
 
 func (s *Service) Create(ctx context.Context, params *pb.AccountParams) (*pb.AccountID, error) {
@@ -64,8 +119,14 @@ func (s *Service) Create(ctx context.Context, params *pb.AccountParams) (*pb.Acc
 			return nil, status.Error(codes.InvalidArgument, "parent account not found")
 		}
 	}
-	return s.datastore.Insert(ctx, a)
+	res, err := s.datastore.Insert(ctx, a)
+	if err != nil {
+		return nil, err
+	}
+	s.requestVerification(ctx, res.Id, a.Email)
+	return res, nil
 }
+
 func (s *Service) UpdateEmail(ctx context.Context, params *pb.AccountParams) (*pb.AccountID, error) {
 	if params == nil {
 		return nil, status.Error(codes.InvalidArgument, "empty payload")
@@ -86,12 +147,24 @@ func (s *Service) UpdateEmail(ctx context.Context, params *pb.AccountParams) (*p
 	if err != nil {
 		return nil, err
 	}
+	prev := a.Email
 	err = s.validator.UpdateEmail(a, params.Email)
 	if err != nil {
 		return nil, err
 	}
+	changed := a.Email != prev
+	if changed {
+		a.EmailVerified = false
+	}
 	a.UpdatedAt = time.Now().Unix()
-	return s.datastore.Update(ctx, &pb.PutAccountParams{Uid: params.Uid, Acct: a})
+	res, err := s.datastore.Update(ctx, &pb.PutAccountParams{Uid: params.Uid, Acct: a})
+	if err != nil {
+		return nil, err
+	}
+	if changed {
+		s.requestVerification(ctx, params.Uid, a.Email)
+	}
+	return res, nil
 }
 func (s *Service) UpdatePassword(ctx context.Context, params *pb.AccountParams) (*pb.AccountID, error) {
 	if params == nil {
@@ -110,11 +183,12 @@ func (s *Service) UpdatePassword(ctx context.Context, params *pb.AccountParams)
 	if !resp.Authorized {
 		return nil, status.Error(codes.PermissionDenied, "permission denied")
 	}
-	a, err := s.GetByUID(ctx, &pb.AccountID{Id: params.Uid, Type: pb.IDType_UID})
+	//already authorized: tokens restricted to UpdatePassword can not read accounts
+	a, err := s.getAccount(ctx, &pb.AccountID{Id: params.Uid, Type: pb.IDType_UID})
 	if err != nil {
 		return nil, err
 	}
-	err = s.validator.UpdatePwd(a, params.Email)
+	err = s.validator.UpdatePwd(a, params.Pwd)
 	if err != nil {
 		return nil, err
 	}
@@ -247,9 +321,21 @@ func (s *Service) UpdateStatus(ctx context.Context, params *pb.AccountPrivileges
 	if err != nil {
 		return nil, err
 	}
-	a.Status = params.Status
+	if err = s.validator.UpdateStatus(a, params.Status); err != nil {
+		return nil, err
+	}
 	a.UpdatedAt = time.Now().Unix()
-	return s.datastore.Update(ctx, &pb.PutAccountParams{Uid: params.Uid, Acct: a})
+	id, err := s.datastore.Update(ctx, &pb.PutAccountParams{Uid: params.Uid, Acct: a})
+	if err != nil {
+		return nil, err
+	}
+	switch a.Status {
+	case pb.AccountStatus_LOCKED, pb.AccountStatus_INACTIVE, pb.AccountStatus_DELETED:
+		if err = s.revokeSessions(params.Uid); err != nil {
+			return nil, err
+		}
+	}
+	return id, nil
 }
 func (s *Service) GetByUID(ctx context.Context, params *pb.AccountID) (*pb.Account, error) {
 	if params == nil {
@@ -268,6 +354,11 @@ func (s *Service) GetByUID(ctx context.Context, params *pb.AccountID) (*pb.Accou
 	if !resp.Authorized {
 		return nil, status.Error(codes.PermissionDenied, "permission denied")
 	}
+	return s.getAccount(ctx, params)
+}
+
+//getAccount reads an account without authorization check
+func (s *Service) getAccount(ctx context.Context, params *pb.AccountID) (*pb.Account, error) {
 	a, err := s.datastore.Get(ctx, params)
 	if err == nil && a != nil {
 		a.Uid = params.Id
@@ -287,15 +378,205 @@ func (s *Service) Authn(ctx context.Context, params *pb.Credentials) (*pb.JwtAut
 	if !s.validator.Authenticate(a, params.Pwd) {
 		return nil, status.Error(codes.Unauthenticated, "incorrect credentials")
 	}
+	if err = checkLogin(a); err != nil {
+		return nil, err
+	}
+	if err = s.checkAudiences(params.Audiences); err != nil {
+		return nil, err
+	}
+	s.rehash(ctx, a, params.Pwd)
 	custom := &pb.Info{Type: "user", Uid: params.Id, Status: a.Status, Roles: a.Roles}
-	accessToken, err := s.jwt.Access.Generate(custom, time.Now(), 0)
+	extra := &jwt.ExtraClaims{ClientID: s.authenticatedClient(ctx, params.ClientId, params.ClientSecret), AuthMethods: []string{jwt.AuthMethodPassword}}
+	if params.DpopProof != "" {
+		proof, err := s.verifyDPoP(params.DpopProof)
+		if err != nil {
+			return nil, err
+		}
+		extra.Confirmation = &jwt.Confirmation{Jkt: proof.Jkt}
+	}
+	if a.Status == pb.AccountStatus_INACTIVE {
+		return s.issueReactivationToken(custom, extra)
+	}
+	if s.validator.PasswordExpired(a, s.jwt.Clock.Now()) {
+		return s.issueRestrictedToken(custom, extra)
+	}
+	return s.issueTokens(ctx, custom, extra, &Family{ID: jwt.NewTokenID(), Audiences: params.Audiences})
+}
+
+//issueRestrictedToken returns an access token only allowing to change an expired password, without refresh token
+func (s *Service) issueRestrictedToken(custom *pb.Info, extra *jwt.ExtraClaims) (*pb.JwtAuthTokens, error) {
+	restricted := *custom
+	restricted.MustChangePwd = true
+	return s.issueAccessToken(&restricted, extra)
+}
+
+//issueAccessToken returns an access token without refresh token
+func (s *Service) issueAccessToken(custom *pb.Info, extra *jwt.ExtraClaims) (*pb.JwtAuthTokens, error) {
+	token, err := s.jwt.Access.GenerateWithParams(custom, s.jwt.Clock.Now(), &jwt.TokenParams{Extra: extra})
 	if err != nil {
 		return nil, status.Error(codes.Internal, "failed to generate access token")
 	}
-	refreshToken, err := s.jwt.Refresh.Generate(custom, time.Now(), 0)
+	return &pb.JwtAuthTokens{Access: token}, nil
+}
+
+//rehash upgrades the hash of an authenticated account if it is below the hashing policy. Failures are logged, the login goes on
+func (s *Service) rehash(ctx context.Context, a *pb.Account, pwd string) {
+	upgraded := *a
+	if !s.validator.Rehash(&upgraded, pwd) {
+		return
+	}
+	if _, err := s.datastore.Update(ctx, &pb.PutAccountParams{Uid: a.Uid, Acct: &upgraded}); err != nil {
+		log.Warnf("failed to rehash password of account '%s': %v", a.Uid, err)
+	}
+}
+
+//verifyDPoP checks a proof sent to the token endpoint
+func (s *Service) verifyDPoP(proof string) (*jwt.DPoPProof, error) {
+	if s.jwt.DPoP == nil {
+		return nil, status.Error(codes.InvalidArgument, "dpop is not supported")
+	}
+	p, err := s.jwt.DPoP.Verify(proof, http.MethodPost, s.jwt.TokenURI, "")
+	if err != nil {
+		return nil, tokenError("dpop_proof", "invalid dpop proof", err)
+	}
+	return p, nil
+}
+
+//checkAudiences returns an InvalidArgument error if an audience was not registered in TokensHandler.Audiences
+func (s *Service) checkAudiences(audiences []string) error {
+	var violations []*errdetails.BadRequest_FieldViolation
+	for _, aud := range audiences {
+		if !jwt.Audience(s.jwt.Audiences).Contains(aud) {
+			violations = append(violations, &errdetails.BadRequest_FieldViolation{
+				Field:       "audiences",
+				Description: fmt.Sprintf("unknown audience '%s'", aud),
+			})
+		}
+	}
+	if len(violations) == 0 {
+		return nil
+	}
+	return badRequest("invalid audiences", violations...)
+}
+
+//Refresh exchanges a refresh token for a new pair of access and refresh tokens. Refresh tokens are single-use: reusing one revokes its whole family
+func (s *Service) Refresh(ctx context.Context, params *pb.JwtAuthTokens) (*pb.JwtAuthTokens, error) {
+	if params == nil || params.Refresh == "" {
+		return nil, status.Error(codes.InvalidArgument, "empty payload")
+	}
+	claims, err := s.jwt.Refresh.Validate(params.Refresh)
+	if err != nil {
+		return nil, tokenError("refresh", "invalid refresh token", err)
+	}
+	if claims.Extra.Confirmation != nil {
+		proof, err := s.verifyDPoP(params.DpopProof)
+		if err != nil {
+			return nil, err
+		}
+		if err = jwt.ConfirmDPoP(claims, proof); err != nil {
+			return nil, tokenError("dpop_proof", "invalid dpop proof", err)
+		}
+	}
+	family, err := s.jwt.Families.Use(ctx, claims.Std.Id)
+	if err != nil {
+		if err == errRefreshTokenReused {
+			log.Warnf("refresh token reused for account '%s': token family revoked", claims.Custom.Uid)
+		}
+		return nil, tokenError("refresh", "invalid refresh token", err)
+	}
+	a, err := s.datastore.Get(ctx, &pb.AccountID{Id: claims.Custom.Uid, Type: pb.IDType_UID})
+	//sessions of LOCKED, INACTIVE and DELETED accounts are revoked when their status changes
+	if err != nil || a.Status == pb.AccountStatus_LOCKED || a.Status == pb.AccountStatus_INACTIVE || a.Status == pb.AccountStatus_DELETED {
+		s.jwt.Families.RevokeFamily(ctx, family.ID)
+		return nil, status.Error(codes.Unauthenticated, "invalid refresh token")
+	}
+	custom := &pb.Info{Type: claims.Custom.Type, Uid: claims.Custom.Uid, Status: a.Status, Roles: a.Roles}
+	if s.validator.PasswordExpired(a, s.jwt.Clock.Now()) {
+		return s.issueRestrictedToken(custom, claims.Extra)
+	}
+	return s.issueTokens(ctx, custom, claims.Extra, family)
+}
+
+//Logout revokes the access token and the refresh token of a session
+func (s *Service) Logout(ctx context.Context, params *pb.JwtAuthTokens) (*pb.AccountID, error) {
+	if params == nil || params.Access == "" {
+		return nil, status.Error(codes.InvalidArgument, "empty payload")
+	}
+	access, err := s.jwt.Access.Validate(params.Access)
+	if err != nil {
+		return nil, tokenError("access", "invalid access token", err)
+	}
+	if err = s.jwt.Revoked.Revoke(access.Std.Id, time.Unix(access.Std.ExpiresAt, 0)); err != nil {
+		return nil, status.Error(codes.Internal, "failed to revoke access token")
+	}
+	if params.Refresh != "" {
+		refresh, err := s.jwt.Refresh.Validate(params.Refresh)
+		if err == nil && refresh.Custom.Uid == access.Custom.Uid {
+			if err = s.jwt.Revoked.Revoke(refresh.Std.Id, time.Unix(refresh.Std.ExpiresAt, 0)); err != nil {
+				return nil, status.Error(codes.Internal, "failed to revoke refresh token")
+			}
+		}
+	}
+	return &pb.AccountID{Id: access.Custom.Uid, Type: pb.IDType_UID}, nil
+}
+
+//RevokeAllSessions revokes every token issued to an account
+func (s *Service) RevokeAllSessions(ctx context.Context, params *pb.AccountID) (*pb.AccountID, error) {
+	if params == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty payload")
+	}
+	authzParams := &authz.Req{
+		Identity:  cotx.GetIdentityFromCtx(ctx),
+		Action:    actions.AccountsRevokeSessions,
+		Path:      []string{"accounts", params.Id},
+		Namespace: "",
+	}
+	resp, err := s.authz.Check(ctx, authzParams)
+	if err != nil {
+		return nil, err
+	}
+	if !resp.Authorized {
+		return nil, status.Error(codes.PermissionDenied, "permission denied")
+	}
+	if _, err = s.datastore.Get(ctx, &pb.AccountID{Id: params.Id, Type: pb.IDType_UID}); err != nil {
+		return nil, err
+	}
+	if err = s.revokeSessions(params.Id); err != nil {
+		return nil, err
+	}
+	return &pb.AccountID{Id: params.Id, Type: pb.IDType_UID}, nil
+}
+
+//revokeSessions revokes every access and refresh token issued to an account until now
+func (s *Service) revokeSessions(uid string) error {
+	validity := s.jwt.Access.Validity()
+	if v := s.jwt.Refresh.Validity(); v > validity {
+		validity = v
+	}
+	now := s.jwt.Clock.Now()
+	if err := s.jwt.Revoked.RevokeAccount(uid, now, now.Add(validity)); err != nil {
+		return status.Error(codes.Internal, "failed to revoke sessions")
+	}
+	return nil
+}
+
+//issueTokens generates an access token for the audiences of the family and a refresh token registered in the family. The session id of extra is the family
+func (s *Service) issueTokens(ctx context.Context, custom *pb.Info, extra *jwt.ExtraClaims, family *Family) (*pb.JwtAuthTokens, error) {
+	now := s.jwt.Clock.Now()
+	ext := *extra
+	ext.SessionID = family.ID
+	accessToken, err := s.jwt.Access.GenerateWithParams(custom, now, &jwt.TokenParams{Audiences: family.Audiences, Extra: &ext})
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to generate access token")
+	}
+	refreshID := jwt.NewTokenID()
+	refreshToken, err := s.jwt.Refresh.GenerateWithParams(custom, now, &jwt.TokenParams{ID: refreshID, Extra: &ext})
 	if err != nil {
 		return nil, status.Error(codes.Internal, "failed to generate refresh token")
 	}
+	if err = s.jwt.Families.Issue(ctx, family, refreshID, now.Add(s.jwt.Refresh.Validity())); err != nil {
+		return nil, status.Error(codes.Unauthenticated, "token family revoked")
+	}
 	tokens := &pb.JwtAuthTokens{Access: accessToken, Refresh: refreshToken}
 	return tokens, nil
 }
@@ -2,17 +2,28 @@ package accounts
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	crand "crypto/rand"
+	"fmt"
 	"log"
 	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
 	"testing"
 	"time"
 
 	jwtgo "github.com/dgrijalva/jwt-go"
 	"github.com/klahssen/authn/pkg/jwt"
+	"github.com/klahssen/authn/pkg/notify"
+	"github.com/klahssen/authn/pkg/passwords"
 	mock "github.com/klahssen/authn/pkg/services/v1/accounts/mock-repo"
 	pb "github.com/klahssen/authn/proto-gen/accounts/apiv1"
 	authz "github.com/klahssen/authn/proto-gen/authz/apiv1"
 	"github.com/klahssen/tester"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -36,14 +47,14 @@ func getNewService() *Service {
 }
 
 func getJwtHandler() *TokensHandler {
-	pf := func() (int, []byte) {
+	pf := func() (string, []byte) {
 		keys := [][]byte{[]byte("abcdef"), []byte("ghijkl")}
 		l := len(keys)
 		n := rand.Intn(l)
 		if n > l {
 			n = 0
 		}
-		return n, keys[n]
+		return fmt.Sprintf("%03d", n+1), keys[n]
 	}
 	kf := func(token *jwtgo.Token) (interface{}, error) {
 		switch token.Header["kid"] {
@@ -53,10 +64,10 @@ func getJwtHandler() *TokensHandler {
 			return []byte("ghijkl"), nil
 		}
 	}
-	sf := func(claims *jwtgo.StandardClaims) error {
-		return claims.Valid()
+	sf := func(claims *jwt.Claims) error {
+		return nil
 	}
-	cf := func(custom *pb.Info) error {
+	cf := func(custom *pb.Info, extra *jwt.ExtraClaims) error {
 		return nil
 	}
 	th := &TokensHandler{}
@@ -167,3 +178,1018 @@ func TestGetByUID(t *testing.T) {
 		}
 	}
 }
+
+func TestRehashOnLogin(t *testing.T) {
+	ctx := context.Background()
+	policy := passwords.HashPolicy{Algorithm: passwords.AlgArgon2id, Argon2id: passwords.Argon2idParams{Memory: 1024, Time: 1, Parallelism: 1, SaltLength: 16, KeyLength: 32}}
+	av := pb.DefaultValidator()
+	av.SetHashPolicy(policy)
+	s, err := New(getMockRepo(), &authSvc{}, av, getJwtHandler())
+	if err != nil {
+		t.Fatalf("failed to instantiate service: %v", err)
+	}
+	id := &pb.AccountID{Id: "acct_002@domain.com", Type: pb.IDType_UID}
+	a, err := s.GetByUID(ctx, id)
+	if err != nil {
+		t.Fatalf("failed to get account: %v", err)
+	}
+	legacy := a.Hash
+	te := tester.NewT(t)
+	_, err = s.Authn(ctx, &pb.Credentials{Id: id.Id, Pwd: "wrong_password"})
+	te.CheckError(0, status.Error(codes.Unauthenticated, "incorrect credentials"), err)
+	if a, _ = s.GetByUID(ctx, id); a.Hash != legacy {
+		t.Errorf("hash replaced after a failed login")
+	}
+	if _, err = s.Authn(ctx, &pb.Credentials{Id: id.Id, Pwd: "password_002"}); err != nil {
+		t.Fatalf("failed to authenticate: %v", err)
+	}
+	if a, _ = s.GetByUID(ctx, id); passwords.Algorithm(a.Hash) != passwords.AlgArgon2id || policy.NeedsRehash(a.Hash) {
+		t.Errorf("expected hash upgraded to the policy, got %s", a.Hash)
+	}
+	upgraded := a.Hash
+	if _, err = s.Authn(ctx, &pb.Credentials{Id: id.Id, Pwd: "password_002"}); err != nil {
+		t.Fatalf("failed to authenticate with the upgraded hash: %v", err)
+	}
+	if a, _ = s.GetByUID(ctx, id); a.Hash != upgraded {
+		t.Errorf("hash replaced although it matches the policy")
+	}
+}
+
+func TestBreachedPasswords(t *testing.T) {
+	ctx := context.Background()
+	//sha1 of "password_003"
+	corpus, err := passwords.LoadBreachCorpus(strings.NewReader("AE9238F7D6B2A0271F78F24195A683868004C282:3\n"))
+	if err != nil {
+		t.Fatalf("failed to load corpus: %v", err)
+	}
+	policy := passwords.DefaultPolicy
+	policy.Breached = corpus
+	av := pb.DefaultValidator()
+	av.SetPasswordPolicy(policy)
+	s, err := New(getMockRepo(), &authSvc{}, av, getJwtHandler())
+	if err != nil {
+		t.Fatalf("failed to instantiate service: %v", err)
+	}
+	breached := status.Error(codes.InvalidArgument, "invalid password")
+	tests := []struct {
+		call func() error
+		err  error
+	}{
+		{func() error {
+			_, err := s.Create(ctx, &pb.AccountParams{Email: "acct_003@domain.com", Pwd: "password_003"})
+			return err
+		}, breached},
+		{func() error {
+			_, err := s.UpdatePassword(ctx, &pb.AccountParams{Uid: "acct_002@domain.com", Pwd: "password_003"})
+			return err
+		}, breached},
+		{func() error {
+			_, err := s.UpdatePassword(ctx, &pb.AccountParams{Uid: "acct_002@domain.com", Pwd: "password_004"})
+			return err
+		}, nil},
+		{func() error {
+			_, err := s.Authn(ctx, &pb.Credentials{Id: "acct_002@domain.com", Pwd: "password_004"})
+			return err
+		}, nil},
+	}
+	te := tester.NewT(t)
+	for ind, test := range tests {
+		err := test.call()
+		te.CheckError(ind, test.err, err)
+		if test.err == nil {
+			continue
+		}
+		details := status.Convert(err).Details()
+		if len(details) != 1 {
+			t.Errorf("test %d: expected 1 detail, received %v", ind, details)
+			continue
+		}
+		br, ok := details[0].(*errdetails.BadRequest)
+		if !ok || len(br.FieldViolations) != 1 || br.FieldViolations[0].Description != "found 3 times in data breaches" {
+			t.Errorf("test %d: unexpected details %v", ind, details)
+		}
+	}
+}
+
+func TestPasswordHistory(t *testing.T) {
+	ctx := context.Background()
+	av := pb.DefaultValidator()
+	av.SetPasswordHistory(2)
+	s, err := New(getMockRepo(), &authSvc{}, av, getJwtHandler())
+	if err != nil {
+		t.Fatalf("failed to instantiate service: %v", err)
+	}
+	reused := status.Error(codes.FailedPrecondition, "password reused")
+	tests := []struct {
+		pwd string
+		err error
+	}{
+		{"password_002", reused},
+		{"password_003", nil},
+		{"password_002", reused},
+		{"password_004", nil},
+		{"password_002", nil},
+	}
+	te := tester.NewT(t)
+	for ind, test := range tests {
+		_, err := s.UpdatePassword(ctx, &pb.AccountParams{Uid: "acct_002@domain.com", Pwd: test.pwd})
+		te.CheckError(ind, test.err, err)
+	}
+	a, err := s.GetByUID(ctx, &pb.AccountID{Id: "acct_002@domain.com", Type: pb.IDType_UID})
+	if err != nil {
+		t.Fatalf("failed to get account: %v", err)
+	}
+	if len(a.HashHistory) != 1 || !passwords.CompareHashAndPassword(a.HashHistory[0], []byte("password_004")) {
+		t.Errorf("expected the previous hash in the history")
+	}
+}
+
+func TestPasswordExpiry(t *testing.T) {
+	av := pb.DefaultValidator()
+	av.SetPasswordMaxAge(time.Hour * 24 * 90)
+	s, err := New(getMockRepo(), &authSvc{}, av, getJwtHandler())
+	if err != nil {
+		t.Fatalf("failed to instantiate service: %v", err)
+	}
+	ctx := context.Background()
+	uid := "acct_002@domain.com"
+	a, err := s.GetByUID(ctx, &pb.AccountID{Id: uid, Type: pb.IDType_UID})
+	if err != nil {
+		t.Fatalf("failed to get account: %v", err)
+	}
+	a.PwdChangedAt = time.Now().AddDate(0, 0, -91).Unix()
+	tokens, err := s.Authn(ctx, &pb.Credentials{Id: uid, Pwd: "password_002"})
+	if err != nil {
+		t.Fatalf("failed to authenticate: %v", err)
+	}
+	if tokens.Refresh != "" {
+		t.Errorf("unexpected refresh token with an expired password")
+	}
+	claims, err := s.jwt.Access.Validate(tokens.Access)
+	if err != nil {
+		t.Fatalf("failed to validate restricted token: %v", err)
+	}
+	if !claims.Custom.MustChangePwd {
+		t.Errorf("expected a restricted token")
+	}
+	s.SetClientAuthFunc(StaticClients(map[string]string{"billing": passwords.HashAndSalt([]byte("secret"))}))
+	introspected, err := s.Introspect(ctx, &pb.IntrospectionReq{Token: tokens.Access, ClientId: "billing", ClientSecret: "secret"})
+	if err != nil || !introspected.Active || !introspected.MustChangePwd {
+		t.Errorf("expected an active restricted token, received %+v, %v", introspected, err)
+	}
+	restricted := context.WithValue(ctx, "jwt", tokens.Access)
+	denied := status.Error(codes.PermissionDenied, "permission denied")
+	tests := []struct {
+		call func() error
+		err  error
+	}{
+		{func() error {
+			_, err := s.UpdateEmail(restricted, &pb.AccountParams{Uid: uid, Email: "acct_003@domain.com"})
+			return err
+		}, denied},
+		{func() error {
+			_, err := s.UpdatePassword(restricted, &pb.AccountParams{Uid: "acct_001@domain.com", Pwd: "password_003"})
+			return err
+		}, denied},
+		{func() error {
+			_, err := s.ExchangeToken(ctx, &pb.TokenExchangeReq{SubjectToken: tokens.Access, ActorToken: tokens.Access, Audiences: []string{"authn"}})
+			return err
+		}, denied},
+		{func() error {
+			_, err := s.UpdatePassword(restricted, &pb.AccountParams{Uid: uid, Pwd: "password_003"})
+			return err
+		}, nil},
+	}
+	te := tester.NewT(t)
+	for ind, test := range tests {
+		te.CheckError(ind, test.err, test.call())
+	}
+	tokens, err = s.Authn(ctx, &pb.Credentials{Id: uid, Pwd: "password_003"})
+	if err != nil {
+		t.Fatalf("failed to authenticate after changing password: %v", err)
+	}
+	if claims, err = s.jwt.Access.Validate(tokens.Access); err != nil || claims.Custom.MustChangePwd || tokens.Refresh == "" {
+		t.Errorf("expected unrestricted tokens after changing password")
+	}
+}
+
+func TestPasswordReset(t *testing.T) {
+	th := getJwtHandler()
+	clock := jwt.NewFakeClock(time.Now())
+	th.Clock = clock
+	s, err := New(getMockRepo(), &authSvc{}, pb.DefaultValidator(), th)
+	if err != nil {
+		t.Fatalf("failed to instantiate service: %v", err)
+	}
+	ctx := context.Background()
+	uid := "acct_002@domain.com"
+	_, err = s.RequestPasswordReset(ctx, &pb.PasswordResetReq{Email: uid})
+	te := tester.NewT(t)
+	te.CheckError(0, status.Error(codes.Unimplemented, "password reset is not enabled"), err)
+	notifier := notify.NewMemNotifier()
+	if err = s.SetPasswordReset(&PasswordReset{Notifier: notifier, URL: "https://app.domain.com/reset?lang=en"}); err != nil {
+		t.Fatalf("failed to enable password reset: %v", err)
+	}
+	tokens, err := s.Authn(ctx, &pb.Credentials{Id: uid, Pwd: "password_002"})
+	if err != nil {
+		t.Fatalf("failed to authenticate: %v", err)
+	}
+	//the session is older than the reset
+	clock.Advance(time.Millisecond)
+	//same response for unknown emails
+	for _, email := range []string{"unknown@domain.com", uid} {
+		resp, err := s.RequestPasswordReset(ctx, &pb.PasswordResetReq{Email: email})
+		if err != nil || resp.Id != "" {
+			t.Errorf("%s: unexpected response %v, %v", email, resp, err)
+		}
+	}
+	s.resets.flush()
+	if msgs := notifier.Messages("unknown@domain.com"); len(msgs) != 0 {
+		t.Errorf("unexpected messages to an unknown email: %v", msgs)
+	}
+	token := func() string {
+		msgs := notifier.Messages(uid)
+		if len(msgs) == 0 {
+			t.Fatalf("no reset link sent")
+		}
+		link, err := url.Parse(msgs[len(msgs)-1].Link)
+		if err != nil || link.Host != "app.domain.com" || link.Query().Get("lang") != "en" {
+			t.Fatalf("invalid reset link %v", msgs[len(msgs)-1].Link)
+		}
+		return link.Query().Get("token")
+	}
+	reset := token()
+	invalid := status.Error(codes.InvalidArgument, "invalid reset token")
+	tests := []struct {
+		params *pb.CompletePasswordResetReq
+		err    error
+	}{
+		{nil, status.Error(codes.InvalidArgument, "empty payload")},
+		{&pb.CompletePasswordResetReq{Token: "abc", Pwd: "password_005"}, invalid},
+		//the token can be used again after a rejected password
+		{&pb.CompletePasswordResetReq{Token: reset, Pwd: "abc"}, status.Error(codes.InvalidArgument, "invalid password")},
+		{&pb.CompletePasswordResetReq{Token: reset, Pwd: "password_005"}, nil},
+		{&pb.CompletePasswordResetReq{Token: reset, Pwd: "password_006"}, invalid},
+	}
+	for ind, test := range tests {
+		_, err := s.CompletePasswordReset(ctx, test.params)
+		te.CheckError(ind, test.err, err)
+	}
+	if _, err = s.Refresh(ctx, tokens); err == nil {
+		t.Errorf("session not revoked after a password reset")
+	}
+	if _, err = s.Authn(ctx, &pb.Credentials{Id: uid, Pwd: "password_005"}); err != nil {
+		t.Errorf("failed to authenticate with the new password: %v", err)
+	}
+	//expired tokens
+	if _, err = s.RequestPasswordReset(ctx, &pb.PasswordResetReq{Email: uid}); err != nil {
+		t.Fatalf("failed to request password reset: %v", err)
+	}
+	s.resets.flush()
+	reset = token()
+	clock.Advance(DefaultResetTokenValidity)
+	_, err = s.CompletePasswordReset(ctx, &pb.CompletePasswordResetReq{Token: reset, Pwd: "password_006"})
+	te.CheckError(len(tests), invalid, err)
+	//requests are dropped once the service is closed
+	s.Close()
+	sent := len(notifier.Messages(uid))
+	if _, err = s.RequestPasswordReset(ctx, &pb.PasswordResetReq{Email: uid}); err != nil {
+		t.Errorf("unexpected error after close: %v", err)
+	}
+	s.resets.flush()
+	if l := len(notifier.Messages(uid)); l != sent {
+		t.Errorf("expected %d messages after close received %d", sent, l)
+	}
+	//no link is bound to the email when the repo does not return the uid
+	s, err = New(&noUIDRepo{getMockRepo()}, &authSvc{}, pb.DefaultValidator(), getJwtHandler())
+	if err != nil {
+		t.Fatalf("failed to instantiate service: %v", err)
+	}
+	defer s.Close()
+	notifier = notify.NewMemNotifier()
+	if err = s.SetPasswordReset(&PasswordReset{Notifier: notifier, URL: "https://app.domain.com/reset"}); err != nil {
+		t.Fatalf("failed to enable password reset: %v", err)
+	}
+	if _, err = s.RequestPasswordReset(ctx, &pb.PasswordResetReq{Email: uid}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	s.resets.flush()
+	if msgs := notifier.Messages(uid); len(msgs) != 0 {
+		t.Errorf("unexpected reset link for an account without uid: %v", msgs)
+	}
+}
+
+//noUIDRepo returns accounts without their uid
+type noUIDRepo struct {
+	pb.AccountRepoServer
+}
+
+func (r *noUIDRepo) Get(ctx context.Context, params *pb.AccountID) (*pb.Account, error) {
+	a, err := r.AccountRepoServer.Get(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	res := *a
+	res.Uid = ""
+	return &res, nil
+}
+
+func TestEmailVerification(t *testing.T) {
+	th := getJwtHandler()
+	clock := jwt.NewFakeClock(time.Now())
+	th.Clock = clock
+	s, err := New(getMockRepo(), &authSvc{}, pb.DefaultValidator(), th)
+	if err != nil {
+		t.Fatalf("failed to instantiate service: %v", err)
+	}
+	ctx := context.Background()
+	_, err = s.VerifyEmail(ctx, &pb.VerifyEmailReq{Code: "abc"})
+	te := tester.NewT(t)
+	te.CheckError(0, status.Error(codes.Unimplemented, "email verification is not enabled"), err)
+	notifier := notify.NewMemNotifier()
+	if err = s.SetEmailVerification(&EmailVerification{Notifier: notifier, URL: "https://app.domain.com/verify"}); err != nil {
+		t.Fatalf("failed to enable email verification: %v", err)
+	}
+	uid := "new@domain.com"
+	if _, err = s.Create(ctx, &pb.AccountParams{Email: uid, Pwd: "password_001"}); err != nil {
+		t.Fatalf("failed to create account: %v", err)
+	}
+	code := func(email string) string {
+		msgs := notifier.Messages(email)
+		if len(msgs) == 0 {
+			t.Fatalf("no verification code sent to %s", email)
+		}
+		link, err := url.Parse(msgs[len(msgs)-1].Link)
+		if err != nil || link.Host != "app.domain.com" {
+			t.Fatalf("invalid verification link %v", msgs[len(msgs)-1].Link)
+		}
+		return link.Query().Get("code")
+	}
+	first := code(uid)
+	resend := []struct {
+		advance time.Duration
+		err     error
+	}{
+		{0, status.Error(codes.ResourceExhausted, "verification code sent recently")},
+		{DefaultResendInterval - time.Second, status.Error(codes.ResourceExhausted, "verification code sent recently")},
+		{time.Second, nil},
+	}
+	for ind, test := range resend {
+		clock.Advance(test.advance)
+		_, err := s.ResendVerification(ctx, &pb.AccountID{Id: uid, Type: pb.IDType_UID})
+		te.CheckError(ind, test.err, err)
+	}
+	second := code(uid)
+	invalid := status.Error(codes.InvalidArgument, "invalid verification code")
+	tests := []struct {
+		params *pb.VerifyEmailReq
+		err    error
+	}{
+		{nil, status.Error(codes.InvalidArgument, "empty payload")},
+		{&pb.VerifyEmailReq{Code: "abc"}, invalid},
+		//replaced by the code sent again
+		{&pb.VerifyEmailReq{Code: first}, invalid},
+		{&pb.VerifyEmailReq{Code: second}, nil},
+		{&pb.VerifyEmailReq{Code: second}, invalid},
+	}
+	for ind, test := range tests {
+		_, err := s.VerifyEmail(ctx, test.params)
+		te.CheckError(ind, test.err, err)
+	}
+	a, err := s.GetByUID(ctx, &pb.AccountID{Id: uid, Type: pb.IDType_UID})
+	if err != nil {
+		t.Fatalf("failed to get account: %v", err)
+	}
+	if !a.EmailVerified || a.Status != pb.AccountStatus_ACTIVE {
+		t.Errorf("expected a verified ACTIVE account, got verified %v, status %v", a.EmailVerified, a.Status)
+	}
+	_, err = s.ResendVerification(ctx, &pb.AccountID{Id: uid, Type: pb.IDType_UID})
+	te.CheckError(len(resend), status.Error(codes.FailedPrecondition, "email already verified"), err)
+	//a new email must be verified again: codes sent to the previous email are not valid anymore
+	clock.Advance(DefaultResendInterval)
+	if _, err = s.ResendVerification(ctx, &pb.AccountID{Id: "acct_001@domain.com", Type: pb.IDType_UID}); err != nil {
+		t.Fatalf("failed to send verification code: %v", err)
+	}
+	old := code("acct_001@domain.com")
+	if _, err = s.UpdateEmail(ctx, &pb.AccountParams{Uid: "acct_001@domain.com", Email: "changed@domain.com"}); err != nil {
+		t.Fatalf("failed to update email: %v", err)
+	}
+	_, err = s.VerifyEmail(ctx, &pb.VerifyEmailReq{Code: old})
+	te.CheckError(len(tests), invalid, err)
+	if _, err = s.VerifyEmail(ctx, &pb.VerifyEmailReq{Code: code("changed@domain.com")}); err != nil {
+		t.Errorf("failed to verify the new email: %v", err)
+	}
+	//codes expire
+	clock.Advance(DefaultResendInterval)
+	if _, err = s.ResendVerification(ctx, &pb.AccountID{Id: "acct_002@domain.com", Type: pb.IDType_UID}); err != nil {
+		t.Fatalf("failed to send verification code: %v", err)
+	}
+	clock.Advance(DefaultVerificationValidity)
+	_, err = s.VerifyEmail(ctx, &pb.VerifyEmailReq{Code: code("acct_002@domain.com")})
+	te.CheckError(len(tests)+1, invalid, err)
+}
+
+func TestAccountStatus(t *testing.T) {
+	s := getNewService()
+	ctx := context.Background()
+	uid := "acct_002@domain.com"
+	creds := &pb.Credentials{Id: uid, Pwd: "password_002"}
+	setStatus := func(st pb.AccountStatus) error {
+		_, err := s.UpdateStatus(ctx, &pb.AccountPrivileges{Uid: uid, Status: st})
+		return err
+	}
+	tokens, err := s.Authn(ctx, creds)
+	if err != nil {
+		t.Fatalf("failed to authenticate: %v", err)
+	}
+	te := tester.NewT(t)
+	te.CheckError(0, status.Error(codes.FailedPrecondition, "invalid status transition"), setStatus(pb.AccountStatus_CREATED))
+	//INACTIVE accounts only get a token to reactivate
+	if err = setStatus(pb.AccountStatus_INACTIVE); err != nil {
+		t.Fatalf("failed to deactivate account: %v", err)
+	}
+	if _, err = s.Refresh(ctx, tokens); err == nil {
+		t.Errorf("session not revoked after deactivation")
+	}
+	tokens, err = s.Authn(ctx, creds)
+	if err != nil {
+		t.Fatalf("failed to authenticate an inactive account: %v", err)
+	}
+	claims, err := s.jwt.Access.Validate(tokens.Access)
+	if err != nil {
+		t.Fatalf("failed to validate reactivation token: %v", err)
+	}
+	if tokens.Refresh != "" || !claims.Extra.Scopes.Contains(ScopeReactivate) || len(claims.Extra.Scopes) != 1 {
+		t.Errorf("expected a reactivation token, got scopes %v", claims.Extra.Scopes)
+	}
+	inactive := context.WithValue(ctx, "jwt", tokens.Access)
+	denied := status.Error(codes.PermissionDenied, "permission denied")
+	tests := []struct {
+		call func() error
+		err  error
+	}{
+		{func() error {
+			_, err := s.UpdatePassword(inactive, &pb.AccountParams{Uid: uid, Pwd: "password_003"})
+			return err
+		}, denied},
+		{func() error {
+			_, err := s.Reactivate(inactive, &pb.AccountID{Id: "acct_001@domain.com", Type: pb.IDType_UID})
+			return err
+		}, denied},
+		{func() error {
+			_, err := s.Reactivate(inactive, &pb.AccountID{Id: uid, Type: pb.IDType_UID})
+			return err
+		}, nil},
+		{func() error {
+			_, err := s.Reactivate(ctx, &pb.AccountID{Id: uid, Type: pb.IDType_UID})
+			return err
+		}, status.Error(codes.FailedPrecondition, "account is not inactive")},
+		//LOCKED and DELETED accounts can not log in
+		{func() error {
+			return setStatus(pb.AccountStatus_LOCKED)
+		}, nil},
+		{func() error {
+			_, err := s.Authn(ctx, creds)
+			return err
+		}, status.Error(codes.PermissionDenied, "account locked")},
+		{func() error {
+			_, err := s.Reactivate(ctx, &pb.AccountID{Id: uid, Type: pb.IDType_UID})
+			return err
+		}, status.Error(codes.FailedPrecondition, "account is not inactive")},
+		{func() error {
+			return setStatus(pb.AccountStatus_DELETED)
+		}, nil},
+		{func() error {
+			_, err := s.Authn(ctx, creds)
+			return err
+		}, status.Error(codes.Unauthenticated, "incorrect credentials")},
+		{func() error {
+			return setStatus(pb.AccountStatus_ACTIVE)
+		}, status.Error(codes.FailedPrecondition, "invalid status transition")},
+	}
+	for ind, test := range tests {
+		te.CheckError(ind+1, test.err, test.call())
+	}
+	if tokens, err = s.Authn(ctx, &pb.Credentials{Id: "acct_001@domain.com", Pwd: "password_001"}); err != nil {
+		t.Errorf("failed to authenticate a CREATED account: %v", err)
+	}
+}
+
+func TestRefresh(t *testing.T) {
+	s := getNewService()
+	ctx := context.Background()
+	tokens, err := s.Authn(ctx, &pb.Credentials{Id: "acct_002@domain.com", Pwd: "password_002"})
+	if err != nil {
+		t.Fatalf("failed to authenticate: %v", err)
+	}
+	invalid := status.Error(codes.Unauthenticated, "invalid refresh token")
+	rotated, err := s.Refresh(ctx, tokens)
+	if err != nil {
+		t.Fatalf("failed to refresh tokens: %v", err)
+	}
+	tests := []struct {
+		params *pb.JwtAuthTokens
+		err    error
+	}{
+		{nil, status.Error(codes.InvalidArgument, "empty payload")},
+		{&pb.JwtAuthTokens{Refresh: "abc"}, status.Error(codes.InvalidArgument, "invalid refresh token")},
+		{&pb.JwtAuthTokens{Refresh: tokens.Access}, invalid},
+		//replaying a used refresh token revokes the whole family, including the latest refresh token
+		{tokens, invalid},
+		{rotated, invalid},
+	}
+	te := tester.NewT(t)
+	for ind, test := range tests {
+		_, err := s.Refresh(ctx, test.params)
+		te.CheckError(ind, test.err, err)
+	}
+}
+
+func TestLogout(t *testing.T) {
+	s := getNewService()
+	ctx := context.Background()
+	tokens, err := s.Authn(ctx, &pb.Credentials{Id: "acct_002@domain.com", Pwd: "password_002"})
+	if err != nil {
+		t.Fatalf("failed to authenticate: %v", err)
+	}
+	if _, err = s.Logout(ctx, tokens); err != nil {
+		t.Fatalf("failed to logout: %v", err)
+	}
+	te := tester.NewT(t)
+	_, err = s.Logout(ctx, tokens)
+	te.CheckError(0, status.Error(codes.Unauthenticated, "invalid access token"), err)
+	_, err = s.Refresh(ctx, tokens)
+	te.CheckError(1, status.Error(codes.Unauthenticated, "invalid refresh token"), err)
+}
+
+func TestRevokeAllSessions(t *testing.T) {
+	s := getNewService()
+	ctx := context.Background()
+	tokens, err := s.Authn(ctx, &pb.Credentials{Id: "acct_002@domain.com", Pwd: "password_002"})
+	if err != nil {
+		t.Fatalf("failed to authenticate: %v", err)
+	}
+	tests := []struct {
+		params *pb.AccountID
+		resp   *pb.AccountID
+		err    error
+	}{
+		{nil, nil, status.Error(codes.InvalidArgument, "empty payload")},
+		{&pb.AccountID{Id: "unknown@domain.com"}, nil, status.Error(codes.NotFound, "account 'unknown@domain.com' not found")},
+		{&pb.AccountID{Id: "acct_002@domain.com"}, &pb.AccountID{Id: "acct_002@domain.com", Type: pb.IDType_UID}, nil},
+	}
+	te := tester.NewT(t)
+	for ind, test := range tests {
+		resp, err := s.RevokeAllSessions(ctx, test.params)
+		te.CheckError(ind, test.err, err)
+		te.DeepEqual(ind, "resp", test.resp, resp)
+	}
+	_, err = s.Refresh(ctx, tokens)
+	te.CheckError(len(tests), status.Error(codes.Unauthenticated, "invalid refresh token"), err)
+	_, err = s.Logout(ctx, tokens)
+	te.CheckError(len(tests)+1, status.Error(codes.Unauthenticated, "invalid access token"), err)
+	//sessions opened right after the revocation are valid
+	if tokens, err = s.Authn(ctx, &pb.Credentials{Id: "acct_002@domain.com", Pwd: "password_002"}); err != nil {
+		t.Fatalf("failed to authenticate: %v", err)
+	}
+	if _, err = s.jwt.Access.Validate(tokens.Access); err != nil {
+		t.Errorf("access token issued after the revocation: %v", err)
+	}
+	if _, err = s.Refresh(ctx, tokens); err != nil {
+		t.Errorf("refresh token issued after the revocation: %v", err)
+	}
+}
+
+func TestIntrospect(t *testing.T) {
+	s := getNewService()
+	s.SetClientAuthFunc(StaticClients(map[string]string{"billing": passwords.HashAndSalt([]byte("secret")), "web": passwords.HashAndSalt([]byte("web_secret"))}))
+	ctx := context.Background()
+	tokens, err := s.Authn(ctx, &pb.Credentials{Id: "acct_002@domain.com", Pwd: "password_002", ClientId: "web", ClientSecret: "web_secret"})
+	if err != nil {
+		t.Fatalf("failed to authenticate: %v", err)
+	}
+	tests := []struct {
+		params    *pb.IntrospectionReq
+		active    bool
+		tokenType string
+		err       error
+	}{
+		{nil, false, "", status.Error(codes.InvalidArgument, "empty payload")},
+		{&pb.IntrospectionReq{Token: tokens.Access, ClientId: "billing", ClientSecret: "wrong"}, false, "", status.Error(codes.Unauthenticated, "invalid client credentials")},
+		{&pb.IntrospectionReq{Token: tokens.Access, ClientId: "unknown", ClientSecret: "secret"}, false, "", status.Error(codes.Unauthenticated, "invalid client credentials")},
+		{&pb.IntrospectionReq{ClientId: "billing", ClientSecret: "secret"}, false, "", status.Error(codes.InvalidArgument, "token is empty")},
+		{&pb.IntrospectionReq{Token: "abc", ClientId: "billing", ClientSecret: "secret"}, false, "", nil},
+		{&pb.IntrospectionReq{Token: tokens.Access, ClientId: "billing", ClientSecret: "secret"}, true, "access_token", nil},
+	}
+	te := tester.NewT(t)
+	for ind, test := range tests {
+		resp, err := s.Introspect(ctx, test.params)
+		te.CheckError(ind, test.err, err)
+		if err != nil {
+			continue
+		}
+		if resp.Active != test.active {
+			t.Errorf("test %d: expected active %v received %v", ind, test.active, resp.Active)
+		}
+		if resp.TokenType != test.tokenType {
+			t.Errorf("test %d: expected token type '%s' received '%s'", ind, test.tokenType, resp.TokenType)
+		}
+		if resp.Active && (resp.Sub != "acct_002@domain.com" || resp.Status != pb.AccountStatus_ACTIVE || resp.ClientId != "web") {
+			t.Errorf("test %d: unexpected introspection response %+v", ind, resp)
+		}
+	}
+	//tokens do not name clients that do not authenticate
+	for _, secret := range []string{"", "wrong"} {
+		spoofed, err := s.Authn(ctx, &pb.Credentials{Id: "acct_002@domain.com", Pwd: "password_002", ClientId: "web", ClientSecret: secret})
+		if err != nil {
+			t.Fatalf("failed to authenticate: %v", err)
+		}
+		resp, err := s.Introspect(ctx, &pb.IntrospectionReq{Token: spoofed.Access, ClientId: "billing", ClientSecret: "secret"})
+		if err != nil || !resp.Active || resp.ClientId != "" {
+			t.Errorf("secret '%s': expected an active token without client_id received %+v, %v", secret, resp, err)
+		}
+	}
+	form := url.Values{"token": {tokens.Access}}
+	req := httptest.NewRequest(http.MethodPost, IntrospectionPath, strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth("billing", "secret")
+	rec := httptest.NewRecorder()
+	s.IntrospectionHandler().ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `"active":true`) {
+		t.Errorf("expected active token over http received %d %s", rec.Code, rec.Body.String())
+	}
+	req = httptest.NewRequest(http.MethodPost, IntrospectionPath, strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	rec = httptest.NewRecorder()
+	s.IntrospectionHandler().ServeHTTP(rec, req)
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("expected status %d for unauthenticated client received %d", http.StatusUnauthorized, rec.Code)
+	}
+}
+
+func TestTokenError(t *testing.T) {
+	tests := []struct {
+		err  error
+		code codes.Code
+	}{
+		{jwt.ErrMalformed, codes.InvalidArgument},
+		{jwt.ErrExpired, codes.Unauthenticated},
+		{jwt.ErrRevoked, codes.Unauthenticated},
+		{jwt.ErrWrongAudience, codes.PermissionDenied},
+	}
+	for ind, test := range tests {
+		st := status.Convert(tokenError("access", "invalid access token", test.err))
+		if st.Code() != test.code {
+			t.Errorf("test %d: expected code %v received %v", ind, test.code, st.Code())
+		}
+		details := st.Details()
+		if len(details) != 1 {
+			t.Errorf("test %d: expected 1 detail received %d", ind, len(details))
+			continue
+		}
+		br, ok := details[0].(*errdetails.BadRequest)
+		if !ok || br.FieldViolations[0].Field != "access" || br.FieldViolations[0].Description != test.err.Error() {
+			t.Errorf("test %d: unexpected details %v", ind, details[0])
+		}
+	}
+}
+
+func TestTokensExpiry(t *testing.T) {
+	th := getJwtHandler()
+	clock := jwt.NewFakeClock(time.Now())
+	th.Clock = clock
+	s, err := New(getMockRepo(), &authSvc{}, pb.DefaultValidator(), th)
+	if err != nil {
+		t.Fatalf("failed to instantiate service with mock repo: %v", err)
+	}
+	ctx := context.Background()
+	tokens, err := s.Authn(ctx, &pb.Credentials{Id: "acct_002@domain.com", Pwd: "password_002"})
+	if err != nil {
+		t.Fatalf("failed to authenticate: %v", err)
+	}
+	clock.Advance(11 * time.Minute)
+	te := tester.NewT(t)
+	_, err = s.Logout(ctx, tokens)
+	te.CheckError(0, tokenError("access", "invalid access token", jwt.ErrExpired), err)
+	rotated, err := s.Refresh(ctx, tokens)
+	te.CheckError(1, nil, err)
+	if _, err = s.Logout(ctx, rotated); err != nil {
+		t.Errorf("expected refreshed access token to be valid received %v", err)
+	}
+	clock.Advance(24 * time.Hour * 4)
+	_, err = s.Refresh(ctx, rotated)
+	te.CheckError(2, tokenError("refresh", "invalid refresh token", jwt.ErrExpired), err)
+}
+
+func TestAudiences(t *testing.T) {
+	th := getJwtHandler()
+	th.Audiences = []string{"billing", "orders"}
+	s, err := New(getMockRepo(), &authSvc{}, pb.DefaultValidator(), th)
+	if err != nil {
+		t.Fatalf("failed to instantiate service: %v", err)
+	}
+	ctx := context.Background()
+	tests := []struct {
+		audiences []string
+		expected  jwt.Audience
+		err       error
+	}{
+		{nil, jwt.Audience{"authn"}, nil},
+		{[]string{"billing"}, jwt.Audience{"billing"}, nil},
+		{[]string{"billing", "orders"}, jwt.Audience{"billing", "orders"}, nil},
+		{[]string{"billing", "shipping"}, nil, status.Error(codes.InvalidArgument, "invalid audiences")},
+	}
+	te := tester.NewT(t)
+	for ind, test := range tests {
+		tokens, err := s.Authn(ctx, &pb.Credentials{Id: "acct_002@domain.com", Pwd: "password_002", Audiences: test.audiences})
+		te.CheckError(ind, test.err, err)
+		if err != nil {
+			continue
+		}
+		//refreshed access tokens keep the audiences of the session
+		tokens, err = s.Refresh(ctx, tokens)
+		if err != nil {
+			t.Fatalf("test %d: failed to refresh tokens: %v", ind, err)
+		}
+		claims, err := s.jwt.Access.Validate(tokens.Access)
+		if err != nil {
+			t.Fatalf("test %d: failed to validate access token: %v", ind, err)
+		}
+		te.DeepEqual(ind, "audiences", test.expected, claims.Std.Audience)
+		te.DeepEqual(ind, "amr", []string{jwt.AuthMethodPassword}, claims.Extra.AuthMethods)
+		if claims.Extra.SessionID == "" {
+			t.Errorf("test %d: missing session id", ind)
+		}
+	}
+}
+
+func TestTokenFormats(t *testing.T) {
+	key := []byte("0123456789abcdef0123456789abcdef")
+	pf := func() (string, []byte) { return "001", key }
+	kf := func(token *jwtgo.Token) (interface{}, error) { return key, nil }
+	sf := func(claims *jwt.Claims) error { return nil }
+	cf := func(custom *pb.Info, extra *jwt.ExtraClaims) error { return nil }
+	ctx := context.Background()
+	te := tester.NewT(t)
+	keys := jwt.NewEncryptionKeys()
+	if err := keys.Add("enc", key); err != nil {
+		t.Fatalf("failed to add encryption key: %v", err)
+	}
+	tests := []struct {
+		format  jwt.Format
+		encrypt bool
+	}{
+		{jwt.FormatJWT, false},
+		{jwt.FormatJWT, true},
+		{jwt.FormatPasetoLocal, false},
+	}
+	for ind, test := range tests {
+		th := &TokensHandler{}
+		var err error
+		if th.Access, err = jwt.NewSymmetricHandler(test.format, "authn", "authn", "access", pf, kf, sf, cf, time.Minute); err != nil {
+			t.Fatalf("test %d: failed to create access handler: %v", ind, err)
+		}
+		if th.Refresh, err = jwt.NewSymmetricHandler(test.format, "authn", "authn", "refresh", pf, kf, sf, cf, time.Hour); err != nil {
+			t.Fatalf("test %d: failed to create refresh handler: %v", ind, err)
+		}
+		if test.encrypt {
+			if th.Refresh, err = jwt.NewJWEHandler(th.Refresh, keys); err != nil {
+				t.Fatalf("test %d: failed to create encrypted refresh handler: %v", ind, err)
+			}
+		}
+		s, err := New(getMockRepo(), &authSvc{}, pb.DefaultValidator(), th)
+		if err != nil {
+			t.Fatalf("test %d: failed to instantiate service: %v", ind, err)
+		}
+		tokens, err := s.Authn(ctx, &pb.Credentials{Id: "acct_002@domain.com", Pwd: "password_002"})
+		if err != nil {
+			t.Fatalf("test %d: failed to authenticate: %v", ind, err)
+		}
+		if tokens, err = s.Refresh(ctx, tokens); err != nil {
+			t.Fatalf("test %d: failed to refresh tokens: %v", ind, err)
+		}
+		if _, err = s.Logout(ctx, tokens); err != nil {
+			t.Fatalf("test %d: failed to logout: %v", ind, err)
+		}
+		_, err = s.Refresh(ctx, tokens)
+		te.CheckError(ind, status.Error(codes.Unauthenticated, "invalid refresh token"), err)
+	}
+}
+
+func TestDPoP(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), crand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate ecdsa key: %v", err)
+	}
+	other, err := ecdsa.GenerateKey(elliptic.P256(), crand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate ecdsa key: %v", err)
+	}
+	ctx := context.Background()
+	uri := "https://authn.example.com/token"
+	proof := func(key *ecdsa.PrivateKey) string {
+		p, err := jwt.NewDPoPProof(key, "POST", uri, "", time.Now())
+		if err != nil {
+			t.Fatalf("failed to create proof: %v", err)
+		}
+		return p
+	}
+	creds := func(proof string) *pb.Credentials {
+		return &pb.Credentials{Id: "acct_002@domain.com", Pwd: "password_002", DpopProof: proof}
+	}
+	te := tester.NewT(t)
+	//without a verifier, clients can not send proofs
+	_, err = getNewService().Authn(ctx, creds(proof(key)))
+	te.CheckError(0, status.Error(codes.InvalidArgument, "dpop is not supported"), err)
+
+	th := getJwtHandler()
+	th.DPoP = jwt.NewDPoPVerifier(nil, time.Minute)
+	th.TokenURI = uri
+	s, err := New(getMockRepo(), &authSvc{}, pb.DefaultValidator(), th)
+	if err != nil {
+		t.Fatalf("failed to instantiate service: %v", err)
+	}
+	_, err = s.Authn(ctx, creds("abc"))
+	te.CheckError(1, status.Error(codes.Unauthenticated, "invalid dpop proof"), err)
+	tokens, err := s.Authn(ctx, creds(proof(key)))
+	if err != nil {
+		t.Fatalf("failed to authenticate: %v", err)
+	}
+	claims, err := s.jwt.Access.Validate(tokens.Access)
+	if err != nil {
+		t.Fatalf("failed to validate access token: %v", err)
+	}
+	jwk, err := jwt.NewJWK("", key.Public())
+	if err != nil {
+		t.Fatalf("failed to create jwk: %v", err)
+	}
+	jkt, _ := jwk.Thumbprint()
+	te.DeepEqual(2, "cnf", &jwt.Confirmation{Jkt: jkt}, claims.Extra.Confirmation)
+	tests := []struct {
+		proof string
+		err   error
+	}{
+		{"", status.Error(codes.Unauthenticated, "invalid dpop proof")},
+		{proof(other), status.Error(codes.Unauthenticated, "invalid dpop proof")},
+		{proof(key), nil},
+	}
+	for ind, test := range tests {
+		_, err = s.Refresh(ctx, &pb.JwtAuthTokens{Refresh: tokens.Refresh, DpopProof: test.proof})
+		te.CheckError(ind+3, test.err, err)
+	}
+	//unbound tokens are still issued to clients not sending proofs
+	tokens, err = s.Authn(ctx, creds(""))
+	if err != nil {
+		t.Fatalf("failed to authenticate: %v", err)
+	}
+	_, err = s.Refresh(ctx, tokens)
+	te.CheckError(6, nil, err)
+}
+
+func TestKeyRingHandler(t *testing.T) {
+	s := getNewService()
+	kr, err := jwt.NewKeyRing(time.Hour)
+	if err != nil {
+		t.Fatalf("failed to create key ring: %v", err)
+	}
+	key, err := ecdsa.GenerateKey(elliptic.P256(), crand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	if err = kr.Stage("k1", key, time.Time{}); err != nil {
+		t.Fatalf("failed to stage k1: %v", err)
+	}
+	tokens, err := s.Authn(context.Background(), &pb.Credentials{Id: "acct_002@domain.com", Pwd: "password_002"})
+	if err != nil {
+		t.Fatalf("failed to authenticate: %v", err)
+	}
+	later := time.Now().Add(time.Hour).Format(time.RFC3339)
+	tests := []struct {
+		method string
+		path   string
+		body   string
+		token  string
+		code   int
+	}{
+		{http.MethodGet, KeyRingPath, "", "", http.StatusUnauthorized},
+		{http.MethodGet, KeyRingPath, "", tokens.Access, http.StatusOK},
+		{http.MethodPost, KeyRingPath, `{"kid":"k2","alg":"HS256"}`, tokens.Access, http.StatusBadRequest},
+		{http.MethodPost, KeyRingPath, `{"kid":"k2","alg":"EdDSA","activates_at":"` + later + `"}`, tokens.Access, http.StatusOK},
+		{http.MethodPost, KeyRingPath, `{"kid":"k2","alg":"ES256"}`, tokens.Access, http.StatusBadRequest},
+		{http.MethodPost, KeyRingPath + "/k3/promote", "", tokens.Access, http.StatusNotFound},
+		{http.MethodPost, KeyRingPath + "/k2/promote", "", tokens.Access, http.StatusOK},
+		{http.MethodPost, KeyRingPath + "/k1/retire", "", tokens.Access, http.StatusBadRequest},
+		{http.MethodDelete, KeyRingPath + "/k1", "", tokens.Access, http.StatusNotFound},
+	}
+	for ind, test := range tests {
+		req := httptest.NewRequest(test.method, test.path, strings.NewReader(test.body))
+		if test.token != "" {
+			req.Header.Set("Authorization", "Bearer "+test.token)
+		}
+		rec := httptest.NewRecorder()
+		s.KeyRingHandler(kr).ServeHTTP(rec, req)
+		if rec.Code != test.code {
+			t.Errorf("test %d: expected status %d received %d %s", ind, test.code, rec.Code, rec.Body.String())
+		}
+	}
+	if kid, _ := kr.Picker()(); kid != "k2" {
+		t.Errorf("expected k2 to sign after its promotion, received %s", kid)
+	}
+	s.authz = &denySvc{}
+	req := httptest.NewRequest(http.MethodPost, KeyRingPath+"/k2/retire", nil)
+	req.Header.Set("Authorization", "Bearer "+tokens.Access)
+	rec := httptest.NewRecorder()
+	s.KeyRingHandler(kr).ServeHTTP(rec, req)
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("expected status %d received %d", http.StatusForbidden, rec.Code)
+	}
+}
+
+type denySvc struct{}
+
+func (d *denySvc) Check(ctx context.Context, params *authz.Req) (*authz.Resp, error) {
+	return &authz.Resp{Authorized: false}, nil
+}
+
+func TestExchangeToken(t *testing.T) {
+	th := getJwtHandler()
+	th.Audiences = []string{"billing"}
+	s, err := New(getMockRepo(), &authSvc{}, pb.DefaultValidator(), th)
+	if err != nil {
+		t.Fatalf("failed to instantiate service: %v", err)
+	}
+	ctx := context.Background()
+	user, err := s.Authn(ctx, &pb.Credentials{Id: "acct_002@domain.com", Pwd: "password_002"})
+	if err != nil {
+		t.Fatalf("failed to authenticate user: %v", err)
+	}
+	service, err := s.Authn(ctx, &pb.Credentials{Id: "acct_001@domain.com", Pwd: "password_001"})
+	if err != nil {
+		t.Fatalf("failed to authenticate service: %v", err)
+	}
+	billing := []string{"billing"}
+	info := &pb.Info{Type: "user", Uid: "acct_002@domain.com", Status: pb.AccountStatus_ACTIVE, Roles: []string{"user"}}
+	scoped, err := s.jwt.Access.GenerateWithParams(info, time.Now(), &jwt.TokenParams{Extra: &jwt.ExtraClaims{Scopes: jwt.Scopes{"invoices:read", "invoices:write"}}})
+	if err != nil {
+		t.Fatalf("failed to generate scoped token: %v", err)
+	}
+	bound, err := s.jwt.Access.GenerateWithParams(info, time.Now(), &jwt.TokenParams{Extra: &jwt.ExtraClaims{Confirmation: &jwt.Confirmation{Jkt: "jkt"}}})
+	if err != nil {
+		t.Fatalf("failed to generate dpop-bound token: %v", err)
+	}
+	tests := []struct {
+		params *pb.TokenExchangeReq
+		err    error
+	}{
+		{nil, status.Error(codes.InvalidArgument, "empty payload")},
+		{&pb.TokenExchangeReq{SubjectToken: user.Access, Audiences: billing}, status.Error(codes.InvalidArgument, "invalid actor token")},
+		{&pb.TokenExchangeReq{SubjectToken: "abc", ActorToken: service.Access, Audiences: billing}, status.Error(codes.InvalidArgument, "invalid subject token")},
+		{&pb.TokenExchangeReq{SubjectToken: user.Access, ActorToken: service.Access}, status.Error(codes.InvalidArgument, "audiences are empty")},
+		{&pb.TokenExchangeReq{SubjectToken: user.Access, ActorToken: service.Access, Audiences: []string{"shipping"}}, status.Error(codes.InvalidArgument, "invalid audiences")},
+		{&pb.TokenExchangeReq{SubjectToken: user.Access, ActorToken: service.Access, Audiences: billing, Roles: []string{"admin"}}, status.Error(codes.InvalidArgument, "roles or scopes not held by the subject token")},
+		//tokens without scopes grant none
+		{&pb.TokenExchangeReq{SubjectToken: user.Access, ActorToken: service.Access, Audiences: billing, Scopes: []string{"invoices:read"}}, status.Error(codes.InvalidArgument, "roles or scopes not held by the subject token")},
+		{&pb.TokenExchangeReq{SubjectToken: user.Access, ActorToken: service.Access, Audiences: billing, Roles: []string{"user"}}, nil},
+		{&pb.TokenExchangeReq{SubjectToken: scoped, ActorToken: service.Access, Audiences: billing, Roles: []string{"user"}, Scopes: []string{"invoices:read"}}, nil},
+		{&pb.TokenExchangeReq{SubjectToken: bound, ActorToken: service.Access, Audiences: billing}, status.Error(codes.InvalidArgument, "dpop-bound subject token")},
+	}
+	te := tester.NewT(t)
+	for ind, test := range tests {
+		_, err := s.ExchangeToken(ctx, test.params)
+		te.CheckError(ind, test.err, err)
+	}
+	resp, err := s.ExchangeToken(ctx, &pb.TokenExchangeReq{SubjectToken: scoped, ActorToken: service.Access, Audiences: billing, Scopes: []string{"invoices:read"}})
+	if err != nil {
+		t.Fatalf("failed to exchange token: %v", err)
+	}
+	subject, err := s.jwt.Access.Validate(scoped)
+	if err != nil {
+		t.Fatalf("failed to validate subject token: %v", err)
+	}
+	claims, err := s.jwt.Access.Validate(resp.AccessToken)
+	if err != nil {
+		t.Fatalf("failed to validate exchanged token: %v", err)
+	}
+	te.DeepEqual(0, "aud", jwt.Audience(billing), claims.Std.Audience)
+	te.DeepEqual(0, "act", &jwt.Actor{Sub: "acct_001@domain.com"}, claims.Extra.Actor)
+	te.DeepEqual(0, "client_id", "acct_001@domain.com", claims.Extra.ClientID)
+	te.DeepEqual(0, "scope", "invoices:read", resp.Scope)
+	if claims.Std.ExpiresAt > subject.Std.ExpiresAt {
+		t.Errorf("exchanged token expires after the subject token")
+	}
+	//the exchanged token can not be widened, and delegation chains are kept
+	_, err = s.ExchangeToken(ctx, &pb.TokenExchangeReq{SubjectToken: resp.AccessToken, ActorToken: service.Access, Audiences: billing, Scopes: []string{"invoices:write"}})
+	te.CheckError(1, status.Error(codes.InvalidArgument, "roles or scopes not held by the subject token"), err)
+	chained, err := s.ExchangeToken(ctx, &pb.TokenExchangeReq{SubjectToken: resp.AccessToken, ActorToken: service.Access, Audiences: billing})
+	if err != nil {
+		t.Fatalf("failed to exchange token: %v", err)
+	}
+	claims, err = s.jwt.Access.Validate(chained.AccessToken)
+	if err != nil {
+		t.Fatalf("failed to validate exchanged token: %v", err)
+	}
+	te.DeepEqual(1, "act", &jwt.Actor{Sub: "acct_001@domain.com", Act: &jwt.Actor{Sub: "acct_001@domain.com"}}, claims.Extra.Actor)
+	s.authz = &denySvc{}
+	_, err = s.ExchangeToken(ctx, &pb.TokenExchangeReq{SubjectToken: user.Access, ActorToken: service.Access, Audiences: billing})
+	te.CheckError(2, status.Error(codes.PermissionDenied, "permission denied"), err)
+}
@@ -0,0 +1,281 @@
+package accounts
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	cotx "github.com/klahssen/authn/pkg/context"
+	"github.com/klahssen/authn/pkg/jwt"
+	"github.com/klahssen/authn/pkg/log"
+	"github.com/klahssen/authn/pkg/notify"
+	"github.com/klahssen/authn/pkg/services/v1/actions"
+	pb "github.com/klahssen/authn/proto-gen/accounts/apiv1"
+	authz "github.com/klahssen/authn/proto-gen/authz/apiv1"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+//Email verification defaults
+const (
+	DefaultVerificationValidity = time.Hour * 24
+	DefaultResendInterval       = time.Minute
+)
+
+var (
+	errUnknownVerificationCode = fmt.Errorf("unknown verification code")
+	errNoVerificationNotifier  = fmt.Errorf("email verification notifier is nil")
+	errInvalidVerificationURL  = fmt.Errorf("invalid email verification url")
+)
+
+//VerificationStore holds email verification codes, by hash: codes are not kept in clear. An account has one valid code at a time
+type VerificationStore interface {
+	//Save the hash of a code sent to the email of an account until exp. It replaces the previous code of the account
+	Save(ctx context.Context, hash, uid, email string, exp time.Time) error
+	//Use consumes a code and returns the account and email it verifies. Used, replaced and expired codes return errUnknownVerificationCode
+	Use(ctx context.Context, hash string) (string, string, error)
+	//LastSent returns when the current code of an account was saved (zero if none)
+	LastSent(ctx context.Context, uid string) (time.Time, error)
+}
+
+type verificationEntry struct {
+	uid   string
+	email string
+	exp   time.Time
+}
+
+type sentCode struct {
+	hash string
+	at   time.Time
+}
+
+//MemVerificationStore is an in-memory VerificationStore
+type MemVerificationStore struct {
+	mu    sync.Mutex
+	codes map[string]*verificationEntry
+	sent  map[string]*sentCode
+	clock jwt.Clock
+}
+
+//NewMemVerificationStore returns an empty in-memory VerificationStore
+func NewMemVerificationStore() *MemVerificationStore {
+	return &MemVerificationStore{codes: map[string]*verificationEntry{}, sent: map[string]*sentCode{}, clock: jwt.SystemClock}
+}
+
+//SetClock replaces the wall clock used to expire codes
+func (m *MemVerificationStore) SetClock(clock jwt.Clock) {
+	if clock == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.clock = clock
+}
+
+//Save a code hash
+func (m *MemVerificationStore) Save(ctx context.Context, hash, uid, email string, exp time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.purge()
+	if prev, ok := m.sent[uid]; ok {
+		delete(m.codes, prev.hash)
+	}
+	m.codes[hash] = &verificationEntry{uid: uid, email: email, exp: exp}
+	m.sent[uid] = &sentCode{hash: hash, at: m.clock.Now()}
+	return nil
+}
+
+//Use a code hash
+func (m *MemVerificationStore) Use(ctx context.Context, hash string) (string, string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.purge()
+	e, ok := m.codes[hash]
+	if !ok {
+		return "", "", errUnknownVerificationCode
+	}
+	delete(m.codes, hash)
+	delete(m.sent, e.uid)
+	return e.uid, e.email, nil
+}
+
+//LastSent returns when the current code of an account was saved
+func (m *MemVerificationStore) LastSent(ctx context.Context, uid string) (time.Time, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.purge()
+	if s, ok := m.sent[uid]; ok {
+		return s.at, nil
+	}
+	return time.Time{}, nil
+}
+
+func (m *MemVerificationStore) purge() {
+	now := m.clock.Now()
+	for hash, e := range m.codes {
+		if !now.Before(e.exp) {
+			delete(m.codes, hash)
+			delete(m.sent, e.uid)
+		}
+	}
+}
+
+//EmailVerification configures the verification of emails
+type EmailVerification struct {
+	//Notifier sends verification codes to users
+	Notifier notify.Notifier
+	//Codes holds verification codes (defaults to an in-memory store)
+	Codes VerificationStore
+	//URL is the page confirming emails: the code is added as its "code" query parameter
+	URL string
+	//Validity of codes (defaults to DefaultVerificationValidity)
+	Validity time.Duration
+	//ResendInterval is the minimum delay between 2 codes sent to an account (defaults to DefaultResendInterval)
+	ResendInterval time.Duration
+}
+
+//SetEmailVerification sends verification codes on Create and UpdateEmail, and enables the VerifyEmail and ResendVerification RPCs. They return Unimplemented errors if it is not set
+func (s *Service) SetEmailVerification(cfg *EmailVerification) error {
+	if cfg == nil || cfg.Notifier == nil {
+		return errNoVerificationNotifier
+	}
+	if _, err := url.Parse(cfg.URL); err != nil || cfg.URL == "" {
+		return errInvalidVerificationURL
+	}
+	v := *cfg
+	if v.Codes == nil {
+		v.Codes = NewMemVerificationStore()
+	}
+	if v.Validity <= 0 {
+		v.Validity = DefaultVerificationValidity
+	}
+	if v.ResendInterval <= 0 {
+		v.ResendInterval = DefaultResendInterval
+	}
+	if c, ok := v.Codes.(jwt.Clocked); ok {
+		c.SetClock(s.jwt.Clock)
+	}
+	s.verification = &v
+	return nil
+}
+
+//VerifyEmail confirms the email of an account with the code sent to it. Accounts in CREATED status become ACTIVE
+func (s *Service) VerifyEmail(ctx context.Context, params *pb.VerifyEmailReq) (*pb.AccountID, error) {
+	if params == nil || params.Code == "" {
+		return nil, status.Error(codes.InvalidArgument, "empty payload")
+	}
+	if s.verification == nil {
+		return nil, status.Error(codes.Unimplemented, "email verification is not enabled")
+	}
+	invalid := status.Error(codes.InvalidArgument, "invalid verification code")
+	uid, email, err := s.verification.Codes.Use(ctx, hashOpaqueToken(params.Code))
+	if err != nil {
+		return nil, invalid
+	}
+	a, err := s.getAccount(ctx, &pb.AccountID{Id: uid, Type: pb.IDType_UID})
+	if err != nil {
+		return nil, invalid
+	}
+	//the email changed since the code was sent
+	if a.Email != email {
+		return nil, invalid
+	}
+	a.EmailVerified = true
+	if a.Status == pb.AccountStatus_CREATED {
+		if err = s.validator.UpdateStatus(a, pb.AccountStatus_ACTIVE); err != nil {
+			return nil, err
+		}
+	}
+	a.UpdatedAt = time.Now().Unix()
+	return s.datastore.Update(ctx, &pb.PutAccountParams{Uid: uid, Acct: a})
+}
+
+//ResendVerification sends a new verification code to the email of an account. Codes can not be sent more often than the resend interval
+func (s *Service) ResendVerification(ctx context.Context, params *pb.AccountID) (*pb.AccountID, error) {
+	if params == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty payload")
+	}
+	if s.verification == nil {
+		return nil, status.Error(codes.Unimplemented, "email verification is not enabled")
+	}
+	authzParams := &authz.Req{
+		Identity:  cotx.GetIdentityFromCtx(ctx),
+		Action:    actions.AccountsResendVerification,
+		Path:      []string{"accounts", params.Id},
+		Namespace: "",
+	}
+	resp, err := s.authz.Check(ctx, authzParams)
+	if err != nil {
+		return nil, err
+	}
+	if !resp.Authorized {
+		return nil, status.Error(codes.PermissionDenied, "permission denied")
+	}
+	a, err := s.getAccount(ctx, &pb.AccountID{Id: params.Id, Type: pb.IDType_UID})
+	if err != nil {
+		return nil, err
+	}
+	if a.EmailVerified {
+		return nil, status.Error(codes.FailedPrecondition, "email already verified")
+	}
+	last, err := s.verification.Codes.LastSent(ctx, params.Id)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to send verification code")
+	}
+	if wait := last.Add(s.verification.ResendInterval).Sub(s.jwt.Clock.Now()); !last.IsZero() && wait > 0 {
+		return nil, resendThrottled(wait)
+	}
+	if err = s.sendVerification(ctx, params.Id, a.Email); err != nil {
+		log.Warnf("failed to send verification code to account '%s': %v", params.Id, err)
+		return nil, status.Error(codes.Internal, "failed to send verification code")
+	}
+	return &pb.AccountID{Id: params.Id, Type: pb.IDType_UID}, nil
+}
+
+//requestVerification sends a verification code if email verification is enabled. Failures are logged: users can ask for another code
+func (s *Service) requestVerification(ctx context.Context, uid, email string) {
+	if s.verification == nil {
+		return
+	}
+	if err := s.sendVerification(ctx, uid, email); err != nil {
+		log.Warnf("failed to send verification code to account '%s': %v", uid, err)
+	}
+}
+
+//sendVerification issues a verification code for the email of an account and sends it
+func (s *Service) sendVerification(ctx context.Context, uid, email string) error {
+	code, hash, err := newOpaqueToken()
+	if err != nil {
+		return err
+	}
+	if err = s.verification.Codes.Save(ctx, hash, uid, email, s.jwt.Clock.Now().Add(s.verification.Validity)); err != nil {
+		return err
+	}
+	link, err := url.Parse(s.verification.URL)
+	if err != nil {
+		return err
+	}
+	q := link.Query()
+	q.Set("code", code)
+	link.RawQuery = q.Encode()
+	return s.verification.Notifier.Notify(ctx, &notify.Message{
+		To:      email,
+		Subject: "Verify your email",
+		Body:    fmt.Sprintf("Follow this link within %s to verify your email: %s\nOr enter this code: %s", s.verification.Validity, link, code),
+		Link:    link.String(),
+	})
+}
+
+//resendThrottled returns a ResourceExhausted error telling when to retry
+func resendThrottled(wait time.Duration) error {
+	st := status.New(codes.ResourceExhausted, "verification code sent recently")
+	st, err := st.WithDetails(&errdetails.RetryInfo{RetryDelay: ptypes.DurationProto(wait)})
+	if err != nil {
+		log.Fatalf("Unexpected error attaching metadata: %v", err)
+	}
+	return st.Err()
+}
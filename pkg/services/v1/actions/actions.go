@@ -1,11 +1,22 @@
 package actions
 
 const (
-	AccountsUpdateEmail    = "accounts.UpdateEmail"
-	AccountsUpdatePassword = "accounts.UpdatePassword"
-	AccountsUpdateStatus   = "accounts.UpdateStatus"
-	AccountsAddRoles       = "accounts.AddRoles"
-	AccountsRemoveRoles    = "accounts.RemoveRoles"
-	AccountsSetRoles       = "accounts.SetRoles"
-	AccountsGetByUID       = "accounts.GetByUID"
+	AccountsUpdateEmail        = "accounts.UpdateEmail"
+	AccountsUpdatePassword     = "accounts.UpdatePassword"
+	AccountsUpdateStatus       = "accounts.UpdateStatus"
+	AccountsAddRoles           = "accounts.AddRoles"
+	AccountsRemoveRoles        = "accounts.RemoveRoles"
+	AccountsSetRoles           = "accounts.SetRoles"
+	AccountsGetByUID           = "accounts.GetByUID"
+	AccountsRevokeSessions     = "accounts.RevokeAllSessions"
+	AccountsExchangeToken      = "accounts.ExchangeToken"
+	AccountsResendVerification = "accounts.ResendVerification"
+	AccountsReactivate         = "accounts.Reactivate"
+)
+
+const (
+	KeysList    = "keys.List"
+	KeysStage   = "keys.Stage"
+	KeysPromote = "keys.Promote"
+	KeysRetire  = "keys.Retire"
 )
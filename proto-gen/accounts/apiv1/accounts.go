@@ -1,6 +1,7 @@
 package apiv1
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/klahssen/authn/pkg/log"
@@ -11,10 +12,14 @@ import (
 	"google.golang.org/grpc/status"
 )
 
+//ViolationPasswordReused is the type of the precondition failure returned when a new password matches a recent one
+const ViolationPasswordReused = "PASSWORD_REUSED"
+
 type GenNewAccountFunc func(params *AccountParams) (*Account, error)
 type ValidateStringFunc func(value string) error
 type HashFunc func(pwd []byte) string
 type ComparePasswordFunc func(hash string, pwd []byte) bool
+type NeedsRehashFunc func(hash string) bool
 
 type AccountValidator struct {
 	generateNew   GenNewAccountFunc
@@ -22,44 +27,95 @@ type AccountValidator struct {
 	validatePwd   ValidateStringFunc
 	hashPassword  HashFunc
 	authn         ComparePasswordFunc
+	needsRehash   NeedsRehashFunc
+	policy        *passwords.Policy
+	history       int
+	maxPwdAge     time.Duration
 }
 
 func (av *AccountValidator) SetNewAccountFunc(fn GenNewAccountFunc) {
 	av.generateNew = fn
 }
+
+//SetPasswordFunc replaces the password policy by fn
 func (av *AccountValidator) SetPasswordFunc(fn ValidateStringFunc) {
 	av.validatePwd = fn
+	av.policy = nil
+}
+
+//SetPasswordPolicy validates passwords against policy, reporting each failed rule as a field violation. Passwords are normalized as the policy requires before being hashed or compared
+func (av *AccountValidator) SetPasswordPolicy(policy passwords.Policy) {
+	p := &policy
+	av.policy = p
+	av.validatePwd = func(pwd string) error {
+		return checkPolicy(p, pwd, "")
+	}
 }
 func (av *AccountValidator) SetEmailFunc(fn ValidateStringFunc) {
 	av.validateEmail = fn
 }
+
+//SetHashFunc replaces the hash of new passwords by fn. It disables rehashing on login, as the default policy only accepts bcrypt hashes: call SetRehashFunc after it to rehash the hashes below the policy of fn
 func (av *AccountValidator) SetHashFunc(fn HashFunc) {
 	av.hashPassword = fn
+	av.needsRehash = nil
 }
 func (av *AccountValidator) SetAuthnFunc(fn ComparePasswordFunc) {
 	av.authn = fn
 }
 
+//SetRehashFunc sets the function checking if a hash is below the hashing policy and must be replaced on login
+func (av *AccountValidator) SetRehashFunc(fn NeedsRehashFunc) {
+	av.needsRehash = fn
+}
+
+//SetPasswordHistory forbids reusing any of the last n passwords of an account: the hashes of the n-1 previous passwords are kept in Account.HashHistory. 0 disables the history
+func (av *AccountValidator) SetPasswordHistory(n int) {
+	if n < 0 {
+		n = 0
+	}
+	av.history = n
+}
+
+//SetPasswordMaxAge sets the age after which passwords expire and must be changed. 0 disables expiry
+func (av *AccountValidator) SetPasswordMaxAge(maxAge time.Duration) {
+	if maxAge < 0 {
+		maxAge = 0
+	}
+	av.maxPwdAge = maxAge
+}
+
+//SetHashPolicy hashes new passwords according to policy, and rehashes on login the passwords whose hash is below it
+func (av *AccountValidator) SetHashPolicy(policy passwords.HashPolicy) {
+	av.hashPassword = policy.Hash
+	av.needsRehash = policy.NeedsRehash
+}
+
 //DefaultValidator returns an AccountValidator with default password and email validation policies
 func DefaultValidator() *AccountValidator {
-	return &AccountValidator{generateNew: genNewAccount, validateEmail: validateEmail, validatePwd: validatePwd, hashPassword: passwords.HashAndSalt, authn: passwords.CompareHashAndPassword}
+	av := &AccountValidator{validateEmail: validateEmail, hashPassword: passwords.HashAndSalt, authn: passwords.CompareHashAndPassword, needsRehash: passwords.NeedsRehash}
+	av.generateNew = av.genNewAccount
+	av.SetPasswordPolicy(passwords.DefaultPolicy)
+	return av
 }
 
-func genNewAccount(params *AccountParams) (*Account, error) {
+//genNewAccount validates params and hashes the password with the current email, password and hash functions of the validator
+func (av *AccountValidator) genNewAccount(params *AccountParams) (*Account, error) {
 	if params == nil {
 		return nil, status.Error(codes.InvalidArgument, "empty payload")
 	}
 	a := &Account{}
-	if err := validateEmail(params.Email); err != nil {
+	if err := av.validateEmail(params.Email); err != nil {
 		return nil, err
 	}
 	a.Email = params.Email
-	if err := validatePwd(params.Pwd); err != nil {
+	if err := av.checkPwd(params.Pwd, params.Email); err != nil {
 		return nil, err
 	}
-	a.Hash = passwords.HashAndSalt([]byte(params.Pwd))
+	a.Hash = av.hashPassword([]byte(av.normalize(params.Pwd)))
 	a.CreatedAt = time.Now().Unix()
 	a.UpdatedAt = time.Now().Unix()
+	a.PwdChangedAt = a.CreatedAt
 	a.Status = AccountStatus_CREATED
 	return a, nil
 }
@@ -80,37 +136,145 @@ func (av *AccountValidator) UpdateEmail(a *Account, email string) error {
 
 //UpdatePwd after format validation
 func (av *AccountValidator) UpdatePwd(a *Account, pwd string) error {
-	if err := av.validatePwd(pwd); err != nil {
+	if err := av.checkPwd(pwd, a.Email); err != nil {
 		return err
 	}
-	a.Hash = av.hashPassword([]byte(pwd))
+	if av.reused(a, pwd) {
+		return passwordReused(av.history)
+	}
+	hash := av.hashPassword([]byte(av.normalize(pwd)))
+	if av.history > 0 {
+		if a.Hash != "" {
+			a.HashHistory = append([]string{a.Hash}, a.HashHistory...)
+		}
+		if len(a.HashHistory) > av.history-1 {
+			a.HashHistory = a.HashHistory[:av.history-1]
+		}
+	}
+	a.Hash = hash
+	a.PwdChangedAt = time.Now().Unix()
 	return nil
 }
 
 //Authenticate a user and return tokens
 func (av *AccountValidator) Authenticate(a *Account, pwd string) bool {
-	return av.authn(a.Hash, []byte(pwd))
+	normalized := av.normalize(pwd)
+	if av.authn(a.Hash, []byte(normalized)) {
+		return true
+	}
+	//hashes computed before normalization was required
+	return normalized != pwd && av.authn(a.Hash, []byte(pwd))
+}
+
+//PasswordExpired checks if the password of an account is older than the maximum age at t. Accounts that never changed their password use their creation date
+func (av *AccountValidator) PasswordExpired(a *Account, t time.Time) bool {
+	if av.maxPwdAge == 0 {
+		return false
+	}
+	changed := a.PwdChangedAt
+	if changed == 0 {
+		changed = a.CreatedAt
+	}
+	return t.Sub(time.Unix(changed, 0)) > av.maxPwdAge
+}
+
+//Rehash replaces the hash of an authenticated account if it is below the hashing policy. It returns true if the hash was replaced.
+//The hash is kept if the new one would still be below the policy, so that a policy the hash function does not follow does not rehash on every login
+func (av *AccountValidator) Rehash(a *Account, pwd string) bool {
+	if av.needsRehash == nil || !av.needsRehash(a.Hash) {
+		return false
+	}
+	hash := av.hashPassword([]byte(av.normalize(pwd)))
+	if hash == "" || av.needsRehash(hash) {
+		return false
+	}
+	a.Hash = hash
+	return true
+}
+
+//reused checks if pwd matches the current password of an account or one of the previous ones kept in its history
+func (av *AccountValidator) reused(a *Account, pwd string) bool {
+	if av.history == 0 {
+		return false
+	}
+	hashes := append([]string{a.Hash}, a.HashHistory...)
+	if len(hashes) > av.history {
+		hashes = hashes[:av.history]
+	}
+	for _, hash := range hashes {
+		if hash != "" && av.Authenticate(&Account{Hash: hash}, pwd) {
+			return true
+		}
+	}
+	return false
+}
+
+//passwordReused returns a FailedPrecondition error with a ViolationPasswordReused detail
+func passwordReused(n int) error {
+	st := status.New(codes.FailedPrecondition, "password reused")
+	pf := &errdetails.PreconditionFailure{}
+	pf.Violations = append(pf.Violations, &errdetails.PreconditionFailure_Violation{
+		Type:        ViolationPasswordReused,
+		Subject:     "pwd",
+		Description: fmt.Sprintf("must differ from the last %d passwords", n),
+	})
+	st, err := st.WithDetails(pf)
+	if err != nil {
+		// If this errored, it will always error
+		// here, so better panic so we can figure
+		// out why than have this silently passing.
+		log.Fatalf("Unexpected error attaching metadata: %v", err)
+	}
+	return st.Err()
+}
+
+//checkPwd validates the password of an account with email
+func (av *AccountValidator) checkPwd(pwd, email string) error {
+	if av.policy != nil {
+		return checkPolicy(av.policy, pwd, email)
+	}
+	return av.validatePwd(pwd)
+}
+
+func (av *AccountValidator) normalize(pwd string) string {
+	if av.policy == nil {
+		return pwd
+	}
+	return av.policy.Normalize(pwd)
 }
 
 func validatePwd(pwd string) error {
-	if err := passwords.ValidateFormat(pwd); err != nil {
-		st := status.New(codes.InvalidArgument, "invalid password")
-		v := &errdetails.BadRequest_FieldViolation{
+	return checkPolicy(&passwords.DefaultPolicy, pwd, "")
+}
+
+//checkPolicy returns an InvalidArgument error with a field violation for each rule of policy the password does not follow. Their hints follow as violations of the "pwd.hints" field
+func checkPolicy(policy *passwords.Policy, pwd, email string) error {
+	violations := policy.Check(pwd, email)
+	if len(violations) == 0 {
+		return nil
+	}
+	st := status.New(codes.InvalidArgument, "invalid password")
+	br := &errdetails.BadRequest{}
+	for _, v := range violations {
+		br.FieldViolations = append(br.FieldViolations, &errdetails.BadRequest_FieldViolation{
 			Field:       "pwd",
-			Description: err.Error(),
+			Description: v.Description,
+		})
+		for _, hint := range v.Hints {
+			br.FieldViolations = append(br.FieldViolations, &errdetails.BadRequest_FieldViolation{
+				Field:       "pwd.hints",
+				Description: hint,
+			})
 		}
-		br := &errdetails.BadRequest{}
-		br.FieldViolations = append(br.FieldViolations, v)
-		st, err := st.WithDetails(br)
-		if err != nil {
-			// If this errored, it will always error
-			// here, so better panic so we can figure
-			// out why than have this silently passing.
-			log.Fatal("Unexpected error attaching metadata: %v", err)
-		}
-		return st.Err()
 	}
-	return nil
+	st, err := st.WithDetails(br)
+	if err != nil {
+		// If this errored, it will always error
+		// here, so better panic so we can figure
+		// out why than have this silently passing.
+		log.Fatalf("Unexpected error attaching metadata: %v", err)
+	}
+	return st.Err()
 }
 
 func validateEmail(email string) error {
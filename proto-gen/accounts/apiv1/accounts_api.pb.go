@@ -95,6 +95,11 @@ type Account struct {
 	Roles         []string      `protobuf:"bytes,7,rep,name=roles,proto3" json:"roles" db:"roles"`
 	Status        AccountStatus `protobuf:"varint,8,opt,name=status,proto3,enum=authn.accounts.v1.AccountStatus" json:"status" db:"status"`
 	ParentAccount string        `protobuf:"bytes,9,opt,name=parent_account,json=parent,proto3" json:"parent" db:"parent"`
+	//hash_history holds the hashes of previous passwords, most recent first
+	HashHistory  []string `protobuf:"bytes,10,rep,name=hash_history,json=-,proto3" json:"-" db:"hash_history"`
+	PwdChangedAt int64    `protobuf:"varint,11,opt,name=pwd_changed_at,json=pwd_upd,proto3" json:"pwd_upd" db:"pwd_upd"`
+	//email_verified is set when the user confirms the verification code sent to the email
+	EmailVerified bool `protobuf:"varint,12,opt,name=email_verified,proto3" json:"email_verified" db:"email_verified"`
 }
 
 func (m *Account) Reset()         { *m = Account{} }
@@ -186,11 +191,34 @@ func (m *Account) GetParentAccount() string {
 	return ""
 }
 
+func (m *Account) GetHashHistory() []string {
+	if m != nil {
+		return m.HashHistory
+	}
+	return nil
+}
+
+func (m *Account) GetPwdChangedAt() int64 {
+	if m != nil {
+		return m.PwdChangedAt
+	}
+	return 0
+}
+
+func (m *Account) GetEmailVerified() bool {
+	if m != nil {
+		return m.EmailVerified
+	}
+	return false
+}
+
 type Info struct {
 	Type   string        `protobuf:"bytes,1,opt,name=type,proto3" json:"type" db:"type"`
 	Uid    string        `protobuf:"bytes,2,opt,name=uid,proto3" json:"uid" db:"uid"`
 	Status AccountStatus `protobuf:"varint,3,opt,name=status,proto3,enum=authn.accounts.v1.AccountStatus" json:"status" db:"status"`
 	Roles  []string      `protobuf:"bytes,4,rep,name=roles,proto3" json:"roles" db:"roles"`
+	//must_change_pwd restricts the token to UpdatePassword: the password expired
+	MustChangePwd bool `protobuf:"varint,5,opt,name=must_change_pwd,proto3" json:"must_change_pwd,omitempty" db:"must_change_pwd"`
 }
 
 func (m *Info) Reset()         { *m = Info{} }
@@ -254,6 +282,13 @@ func (m *Info) GetRoles() []string {
 	return nil
 }
 
+func (m *Info) GetMustChangePwd() bool {
+	if m != nil {
+		return m.MustChangePwd
+	}
+	return false
+}
+
 type MultiAccounts struct {
 	Accounts []*Account `protobuf:"bytes,1,rep,name=accounts,proto3" json:"accounts" db:"accounts"`
 }
@@ -536,6 +571,8 @@ func (m *AccountPrivileges) GetStatus() AccountStatus {
 type JwtAuthTokens struct {
 	Access  string `protobuf:"bytes,1,opt,name=access,proto3" json:"access,omitempty"`
 	Refresh string `protobuf:"bytes,2,opt,name=refresh,proto3" json:"refresh,omitempty"`
+	//dpop_proof is a DPoP proof (RFC 9449) required to refresh tokens bound to a key
+	DpopProof string `protobuf:"bytes,3,opt,name=dpop_proof,json=dpopProof,proto3" json:"dpop_proof,omitempty"`
 }
 
 func (m *JwtAuthTokens) Reset()         { *m = JwtAuthTokens{} }
@@ -585,10 +622,24 @@ func (m *JwtAuthTokens) GetRefresh() string {
 	return ""
 }
 
+func (m *JwtAuthTokens) GetDpopProof() string {
+	if m != nil {
+		return m.DpopProof
+	}
+	return ""
+}
+
 //Credentials holds credentials to authenticate a user
 type Credentials struct {
 	Id  string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	Pwd string `protobuf:"bytes,2,opt,name=pwd,proto3" json:"pwd,omitempty"`
+	//audiences are the downstream services the access token is issued for (defaults to the service's own audience)
+	Audiences []string `protobuf:"bytes,3,rep,name=audiences,proto3" json:"audiences,omitempty"`
+	//dpop_proof is a DPoP proof (RFC 9449): issued tokens are bound to its key
+	DpopProof string `protobuf:"bytes,4,opt,name=dpop_proof,json=dpopProof,proto3" json:"dpop_proof,omitempty"`
+	//client_id is the client the tokens are issued to (client_id claim, RFC 9068). It is only set in tokens if the client authenticates with client_secret
+	ClientId     string `protobuf:"bytes,5,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	ClientSecret string `protobuf:"bytes,6,opt,name=client_secret,json=clientSecret,proto3" json:"client_secret,omitempty"`
 }
 
 func (m *Credentials) Reset()         { *m = Credentials{} }
@@ -638,23 +689,54 @@ func (m *Credentials) GetPwd() string {
 	return ""
 }
 
-type PutAccountParams struct {
-	Uid  string   `protobuf:"bytes,1,opt,name=uid,proto3" json:"uid,omitempty"`
-	Acct *Account `protobuf:"bytes,2,opt,name=acct,proto3" json:"acct,omitempty"`
+func (m *Credentials) GetAudiences() []string {
+	if m != nil {
+		return m.Audiences
+	}
+	return nil
 }
 
-func (m *PutAccountParams) Reset()         { *m = PutAccountParams{} }
-func (m *PutAccountParams) String() string { return proto.CompactTextString(m) }
-func (*PutAccountParams) ProtoMessage()    {}
-func (*PutAccountParams) Descriptor() ([]byte, []int) {
+func (m *Credentials) GetDpopProof() string {
+	if m != nil {
+		return m.DpopProof
+	}
+	return ""
+}
+
+func (m *Credentials) GetClientId() string {
+	if m != nil {
+		return m.ClientId
+	}
+	return ""
+}
+
+func (m *Credentials) GetClientSecret() string {
+	if m != nil {
+		return m.ClientSecret
+	}
+	return ""
+}
+
+//IntrospectionReq holds a token to introspect and the credentials of the confidential client asking (RFC 7662)
+type IntrospectionReq struct {
+	Token         string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	TokenTypeHint string `protobuf:"bytes,2,opt,name=token_type_hint,json=tokenTypeHint,proto3" json:"token_type_hint,omitempty"`
+	ClientId      string `protobuf:"bytes,3,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	ClientSecret  string `protobuf:"bytes,4,opt,name=client_secret,json=clientSecret,proto3" json:"client_secret,omitempty"`
+}
+
+func (m *IntrospectionReq) Reset()         { *m = IntrospectionReq{} }
+func (m *IntrospectionReq) String() string { return proto.CompactTextString(m) }
+func (*IntrospectionReq) ProtoMessage()    {}
+func (*IntrospectionReq) Descriptor() ([]byte, []int) {
 	return fileDescriptor_3b32f31c7eac1477, []int{9}
 }
-func (m *PutAccountParams) XXX_Unmarshal(b []byte) error {
+func (m *IntrospectionReq) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *PutAccountParams) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *IntrospectionReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_PutAccountParams.Marshal(b, m, deterministic)
+		return xxx_messageInfo_IntrospectionReq.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -664,1314 +746,4632 @@ func (m *PutAccountParams) XXX_Marshal(b []byte, deterministic bool) ([]byte, er
 		return b[:n], nil
 	}
 }
-func (m *PutAccountParams) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_PutAccountParams.Merge(m, src)
+func (m *IntrospectionReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_IntrospectionReq.Merge(m, src)
 }
-func (m *PutAccountParams) XXX_Size() int {
+func (m *IntrospectionReq) XXX_Size() int {
 	return m.Size()
 }
-func (m *PutAccountParams) XXX_DiscardUnknown() {
-	xxx_messageInfo_PutAccountParams.DiscardUnknown(m)
+func (m *IntrospectionReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_IntrospectionReq.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_PutAccountParams proto.InternalMessageInfo
+var xxx_messageInfo_IntrospectionReq proto.InternalMessageInfo
 
-func (m *PutAccountParams) GetUid() string {
+func (m *IntrospectionReq) GetToken() string {
 	if m != nil {
-		return m.Uid
+		return m.Token
 	}
 	return ""
 }
 
-func (m *PutAccountParams) GetAcct() *Account {
+func (m *IntrospectionReq) GetTokenTypeHint() string {
 	if m != nil {
-		return m.Acct
+		return m.TokenTypeHint
 	}
-	return nil
+	return ""
 }
 
-func init() {
-	proto.RegisterEnum("authn.accounts.v1.AccountStatus", AccountStatus_name, AccountStatus_value)
-	proto.RegisterEnum("authn.accounts.v1.IDType", IDType_name, IDType_value)
-	proto.RegisterType((*Account)(nil), "authn.accounts.v1.Account")
-	proto.RegisterType((*Info)(nil), "authn.accounts.v1.Info")
-	proto.RegisterType((*MultiAccounts)(nil), "authn.accounts.v1.MultiAccounts")
-	proto.RegisterType((*AccountID)(nil), "authn.accounts.v1.AccountID")
-	proto.RegisterType((*AccountIDs)(nil), "authn.accounts.v1.AccountIDs")
-	proto.RegisterType((*AccountParams)(nil), "authn.accounts.v1.AccountParams")
-	proto.RegisterType((*AccountPrivileges)(nil), "authn.accounts.v1.AccountPrivileges")
-	proto.RegisterType((*JwtAuthTokens)(nil), "authn.accounts.v1.JwtAuthTokens")
-	proto.RegisterType((*Credentials)(nil), "authn.accounts.v1.Credentials")
-	proto.RegisterType((*PutAccountParams)(nil), "authn.accounts.v1.PutAccountParams")
+func (m *IntrospectionReq) GetClientId() string {
+	if m != nil {
+		return m.ClientId
+	}
+	return ""
 }
 
-func init() { proto.RegisterFile("accounts/v1/accounts_api.proto", fileDescriptor_3b32f31c7eac1477) }
-
-var fileDescriptor_3b32f31c7eac1477 = []byte{
-	// 971 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x56, 0xcf, 0x6e, 0xdb, 0xc6,
-	0x13, 0x36, 0x45, 0xfd, 0x1d, 0xc5, 0x86, 0xb2, 0xbf, 0x5f, 0x5b, 0x45, 0x48, 0x44, 0x81, 0x69,
-	0x01, 0x37, 0xa8, 0x24, 0x58, 0xbd, 0xb4, 0xbd, 0x14, 0x92, 0x28, 0xa4, 0x8c, 0xff, 0x44, 0xa5,
-	0xe5, 0x1e, 0x7a, 0x31, 0xd6, 0xe2, 0x5a, 0x22, 0x22, 0x93, 0x04, 0xb9, 0x94, 0xe1, 0xb7, 0xe8,
-	0x33, 0xf4, 0x45, 0x7a, 0xed, 0xd1, 0xc7, 0x1e, 0x0a, 0xa2, 0xb0, 0x6f, 0x3a, 0xea, 0x09, 0x8a,
-	0xdd, 0xe5, 0x32, 0x49, 0x23, 0x4b, 0x09, 0xec, 0xdb, 0xce, 0xec, 0xcc, 0xb7, 0xb3, 0xdf, 0x7c,
-	0x43, 0x2e, 0xd4, 0xf1, 0x78, 0xec, 0x45, 0x2e, 0x0d, 0xdb, 0xf3, 0xbd, 0xb6, 0x5c, 0x9f, 0x62,
-	0xdf, 0x69, 0xf9, 0x81, 0x47, 0x3d, 0xf4, 0x18, 0x47, 0x74, 0xea, 0xb6, 0xe4, 0x4e, 0x6b, 0xbe,
-	0x57, 0x6b, 0x4e, 0x1c, 0x3a, 0x8d, 0xce, 0x5a, 0x63, 0xef, 0xa2, 0x3d, 0xf1, 0x26, 0x5e, 0x9b,
-	0x47, 0x9e, 0x45, 0xe7, 0xdc, 0xe2, 0x06, 0x5f, 0x09, 0x04, 0xfd, 0x77, 0x15, 0x0a, 0x5d, 0x91,
-	0x8e, 0xbe, 0x02, 0x35, 0x72, 0xec, 0xaa, 0xd2, 0x50, 0x76, 0x4b, 0xbd, 0xff, 0x2d, 0x62, 0x8d,
-	0x99, 0xcb, 0x58, 0x2b, 0xda, 0x67, 0x3f, 0xe8, 0x91, 0x63, 0xeb, 0x16, 0x73, 0xa0, 0x26, 0xe4,
-	0xc8, 0x05, 0x76, 0x66, 0x55, 0x95, 0x07, 0x7e, 0xb1, 0x88, 0x35, 0xe1, 0x58, 0xc6, 0x1a, 0xb0,
-	0x50, 0x6e, 0xe8, 0x96, 0x70, 0xa2, 0xe7, 0x90, 0x9d, 0xe2, 0x70, 0x5a, 0xcd, 0xf2, 0x68, 0xb4,
-	0x88, 0x35, 0xa5, 0xb9, 0x8c, 0xb5, 0x12, 0x8b, 0x64, 0x1b, 0xba, 0xa5, 0x34, 0x51, 0x1b, 0x60,
-	0x1c, 0x10, 0x4c, 0x89, 0x7d, 0x8a, 0x69, 0x35, 0xd7, 0x50, 0x76, 0xd5, 0xde, 0x67, 0x8b, 0x58,
-	0xcb, 0x32, 0xaf, 0x8c, 0x66, 0x6b, 0xdd, 0xe2, 0x2e, 0xf4, 0x0d, 0x40, 0xe4, 0xdb, 0x32, 0x21,
-	0xcf, 0x13, 0x44, 0xc9, 0xfe, 0xdb, 0x92, 0x7d, 0x5e, 0xb2, 0xcf, 0x4b, 0x0e, 0xbc, 0x19, 0x09,
-	0xab, 0x85, 0x86, 0x2a, 0x4b, 0xe6, 0x0e, 0x59, 0x32, 0x37, 0x74, 0x4b, 0x38, 0xd1, 0x31, 0xe4,
-	0x43, 0x8a, 0x69, 0x14, 0x56, 0x8b, 0x0d, 0x65, 0x77, 0xa7, 0xd3, 0x68, 0x7d, 0xc0, 0x73, 0x2b,
-	0x21, 0xed, 0x98, 0xc7, 0xf5, 0x9e, 0x2c, 0x62, 0x2d, 0xc9, 0x59, 0xc6, 0x5a, 0x99, 0x41, 0x0a,
-	0x4b, 0xb7, 0x12, 0x37, 0xfa, 0x1e, 0x76, 0x7c, 0x1c, 0x10, 0x97, 0x9e, 0x26, 0x30, 0xd5, 0x12,
-	0x67, 0x84, 0xa7, 0x8a, 0x1d, 0x99, 0x2a, 0x2c, 0xdd, 0x4a, 0xdc, 0xfa, 0xdf, 0x0a, 0x64, 0x4d,
-	0xf7, 0xdc, 0x43, 0x5f, 0x43, 0x96, 0x5e, 0xf9, 0x24, 0x69, 0x11, 0x27, 0x88, 0xd9, 0x92, 0x20,
-	0xb6, 0xd6, 0x2d, 0xee, 0x92, 0xcd, 0xcc, 0x6c, 0x68, 0xe6, 0xdb, 0xab, 0xaa, 0x0f, 0x77, 0xd5,
-	0x94, 0xee, 0xec, 0xc7, 0xd0, 0xad, 0x9f, 0xc3, 0xf6, 0x61, 0x34, 0xa3, 0x4e, 0x72, 0x4e, 0x88,
-	0x4e, 0xa0, 0x28, 0xcf, 0xaf, 0x2a, 0x0d, 0x75, 0xb7, 0xdc, 0xa9, 0xdd, 0x5d, 0x56, 0xef, 0xd9,
-	0x22, 0xd6, 0xd2, 0xf8, 0x65, 0xac, 0x6d, 0xb3, 0x13, 0xa4, 0xad, 0x5b, 0xe9, 0x96, 0xfe, 0x0a,
-	0x4a, 0x49, 0x8e, 0x69, 0xa0, 0x1d, 0xc8, 0x48, 0xad, 0x5b, 0x19, 0xae, 0x6a, 0x41, 0x6d, 0x86,
-	0xd3, 0xf0, 0x64, 0xc5, 0x79, 0xa6, 0x31, 0xba, 0xf2, 0x89, 0xa0, 0x57, 0x3f, 0x04, 0x48, 0xb1,
-	0x42, 0x54, 0x01, 0xd5, 0xb1, 0x45, 0xad, 0x25, 0x8b, 0x2d, 0x3f, 0x15, 0x0e, 0xc3, 0x76, 0x02,
-	0x37, 0xc4, 0x01, 0xbe, 0xe0, 0x88, 0xe9, 0x2c, 0x8a, 0x4e, 0xfd, 0x5f, 0x8e, 0x1d, 0x6f, 0xa9,
-	0x9c, 0xae, 0x0a, 0xa8, 0xfe, 0xa5, 0x2d, 0x46, 0xd1, 0x62, 0x4b, 0xf4, 0x39, 0x24, 0xb2, 0x11,
-	0x13, 0x97, 0x8a, 0x28, 0x82, 0xc7, 0xf2, 0x88, 0xc0, 0x99, 0x3b, 0x33, 0x32, 0x21, 0x77, 0x1c,
-	0x23, 0x7a, 0x97, 0xe1, 0x97, 0x49, 0x26, 0xe2, 0xbb, 0x4f, 0x95, 0x89, 0xd4, 0x82, 0xde, 0x85,
-	0xed, 0x57, 0x97, 0xb4, 0x1b, 0xd1, 0xe9, 0xc8, 0x7b, 0x43, 0xdc, 0x90, 0xd5, 0x87, 0xc7, 0x63,
-	0x12, 0x86, 0xc9, 0xa9, 0x89, 0x85, 0xaa, 0x50, 0x08, 0xc8, 0x79, 0x40, 0xc2, 0x69, 0x72, 0x43,
-	0x69, 0xea, 0x6d, 0x28, 0xf7, 0x03, 0x62, 0x13, 0x97, 0x3a, 0x78, 0x16, 0x7e, 0xd0, 0xb9, 0x84,
-	0x82, 0x4c, 0x4a, 0x81, 0x3e, 0x82, 0xca, 0x30, 0xa2, 0x9b, 0x08, 0x6d, 0x41, 0x16, 0x8f, 0xc7,
-	0x94, 0x27, 0xae, 0x55, 0x98, 0xc5, 0xe3, 0x5e, 0xbc, 0x4e, 0x7b, 0x24, 0xae, 0x88, 0xca, 0x50,
-	0xe8, 0x5b, 0x83, 0xee, 0x68, 0x60, 0x54, 0xb6, 0x10, 0x40, 0xbe, 0xdb, 0x1f, 0x99, 0xbf, 0x0c,
-	0x2a, 0x0a, 0x5b, 0x1f, 0xbc, 0xee, 0xef, 0x0f, 0x8c, 0x4a, 0x06, 0x3d, 0x82, 0xa2, 0x79, 0x94,
-	0xec, 0xa8, 0x2c, 0xc5, 0x18, 0x1c, 0x0c, 0x58, 0x4a, 0xf6, 0xc5, 0x53, 0xc8, 0x0b, 0x11, 0xa0,
-	0x02, 0xa8, 0x27, 0x26, 0x43, 0x29, 0x41, 0x6e, 0x70, 0xd8, 0x35, 0x0f, 0x2a, 0x4a, 0xe7, 0x3a,
-	0x07, 0x65, 0x39, 0x11, 0xdd, 0xa1, 0x89, 0x7e, 0x82, 0x7c, 0x9f, 0x7f, 0x22, 0xd1, 0x1a, 0xf2,
-	0xc5, 0x65, 0x6b, 0x4f, 0xef, 0x8e, 0x30, 0x0d, 0x74, 0x08, 0xe5, 0x13, 0xfe, 0xed, 0x1c, 0x70,
-	0x09, 0xdd, 0x17, 0x6e, 0x08, 0x3b, 0x02, 0x6e, 0x88, 0xc3, 0xf0, 0xd2, 0x0b, 0xec, 0x7b, 0x23,
-	0x1e, 0x41, 0xb1, 0x6b, 0xdb, 0x16, 0x57, 0xde, 0x97, 0x6b, 0xb0, 0x52, 0x1d, 0x6f, 0xc0, 0xfb,
-	0x19, 0xca, 0x16, 0xb9, 0xf0, 0xe6, 0xe4, 0xe1, 0x20, 0x8f, 0xa0, 0x78, 0x4c, 0xe8, 0xc3, 0xe1,
-	0x59, 0xf0, 0x48, 0x90, 0x98, 0x68, 0xeb, 0x21, 0x30, 0x0d, 0x28, 0xbe, 0x24, 0xb4, 0x77, 0x75,
-	0x62, 0x1a, 0x68, 0x6d, 0x64, 0x6d, 0x8d, 0xf8, 0x91, 0x09, 0x39, 0x36, 0xbd, 0x2e, 0xaa, 0xaf,
-	0x08, 0x7a, 0x67, 0x2e, 0x6b, 0xab, 0xba, 0xfe, 0xde, 0xe8, 0x77, 0xfe, 0x50, 0x53, 0x49, 0x5b,
-	0xc4, 0xf7, 0x50, 0x0f, 0xf2, 0xa6, 0x1b, 0x92, 0x80, 0xa2, 0x35, 0x05, 0x6c, 0xb8, 0xe4, 0x3e,
-	0xe4, 0x05, 0x71, 0xe8, 0xf9, 0x8a, 0xb8, 0xff, 0x7e, 0x06, 0x36, 0x80, 0xfd, 0x08, 0xea, 0x4b,
-	0x42, 0xef, 0x41, 0xd6, 0x3e, 0xa7, 0x9c, 0xff, 0xcd, 0xd0, 0xb3, 0x75, 0x28, 0xab, 0xe9, 0x7a,
-	0xff, 0x37, 0x68, 0x40, 0xde, 0x20, 0x33, 0x42, 0xc9, 0x86, 0x82, 0x36, 0x11, 0x54, 0x16, 0x28,
-	0x1f, 0x55, 0xd5, 0xfa, 0xed, 0xde, 0xc1, 0x9f, 0x37, 0x75, 0xe5, 0xfa, 0xa6, 0xae, 0xfc, 0x73,
-	0x53, 0x57, 0x7e, 0xbb, 0xad, 0x6f, 0x5d, 0xdf, 0xd6, 0xb7, 0xfe, 0xba, 0xad, 0x6f, 0xfd, 0xda,
-	0x79, 0xe7, 0xdd, 0xf9, 0x66, 0x86, 0xa7, 0x61, 0x48, 0xdc, 0x36, 0xc7, 0x12, 0x2f, 0xd0, 0xe6,
-	0x84, 0xd9, 0xf2, 0x39, 0x8b, 0x7d, 0x67, 0xbe, 0x77, 0x96, 0xe7, 0x3b, 0xdf, 0xfe, 0x1b, 0x00,
-	0x00, 0xff, 0xff, 0x49, 0xb3, 0x23, 0x40, 0xe7, 0x0a, 0x00, 0x00,
+func (m *IntrospectionReq) GetClientSecret() string {
+	if m != nil {
+		return m.ClientSecret
+	}
+	return ""
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
+//IntrospectionResp is the RFC 7662 introspection response, extended with account info
+type IntrospectionResp struct {
+	Active    bool          `protobuf:"varint,1,opt,name=active,proto3" json:"active"`
+	Sub       string        `protobuf:"bytes,2,opt,name=sub,proto3" json:"sub,omitempty"`
+	Exp       int64         `protobuf:"varint,3,opt,name=exp,proto3" json:"exp,omitempty"`
+	Iat       int64         `protobuf:"varint,4,opt,name=iat,proto3" json:"iat,omitempty"`
+	Nbf       int64         `protobuf:"varint,5,opt,name=nbf,proto3" json:"nbf,omitempty"`
+	Scope     string        `protobuf:"bytes,6,opt,name=scope,proto3" json:"scope,omitempty"`
+	ClientId  string        `protobuf:"bytes,7,opt,name=client_id,proto3" json:"client_id,omitempty"`
+	Iss       string        `protobuf:"bytes,8,opt,name=iss,proto3" json:"iss,omitempty"`
+	Aud       []string      `protobuf:"bytes,9,rep,name=aud,proto3" json:"aud,omitempty"`
+	Jti       string        `protobuf:"bytes,10,opt,name=jti,proto3" json:"jti,omitempty"`
+	TokenType string        `protobuf:"bytes,11,opt,name=token_type,proto3" json:"token_type,omitempty"`
+	Type      string        `protobuf:"bytes,12,opt,name=type,proto3" json:"type,omitempty"`
+	Status    AccountStatus `protobuf:"varint,13,opt,name=status,proto3,enum=authn.accounts.v1.AccountStatus" json:"status"`
+	Roles     []string      `protobuf:"bytes,14,rep,name=roles,proto3" json:"roles,omitempty"`
+	//must_change_pwd tells resource servers the token only allows to change an expired password
+	MustChangePwd bool `protobuf:"varint,15,opt,name=must_change_pwd,proto3" json:"must_change_pwd,omitempty"`
+}
+
+func (m *IntrospectionResp) Reset()         { *m = IntrospectionResp{} }
+func (m *IntrospectionResp) String() string { return proto.CompactTextString(m) }
+func (*IntrospectionResp) ProtoMessage()    {}
+func (*IntrospectionResp) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b32f31c7eac1477, []int{10}
+}
+func (m *IntrospectionResp) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *IntrospectionResp) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_IntrospectionResp.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *IntrospectionResp) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_IntrospectionResp.Merge(m, src)
+}
+func (m *IntrospectionResp) XXX_Size() int {
+	return m.Size()
+}
+func (m *IntrospectionResp) XXX_DiscardUnknown() {
+	xxx_messageInfo_IntrospectionResp.DiscardUnknown(m)
+}
 
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
+var xxx_messageInfo_IntrospectionResp proto.InternalMessageInfo
 
-// AccountsAPIClient is the client API for AccountsAPI service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type AccountsAPIClient interface {
-	Create(ctx context.Context, in *AccountParams, opts ...grpc.CallOption) (*AccountID, error)
-	UpdateEmail(ctx context.Context, in *AccountParams, opts ...grpc.CallOption) (*AccountID, error)
-	UpdatePassword(ctx context.Context, in *AccountParams, opts ...grpc.CallOption) (*AccountID, error)
-	AddRoles(ctx context.Context, in *AccountPrivileges, opts ...grpc.CallOption) (*AccountID, error)
-	RemoveRoles(ctx context.Context, in *AccountPrivileges, opts ...grpc.CallOption) (*AccountID, error)
-	SetRoles(ctx context.Context, in *AccountPrivileges, opts ...grpc.CallOption) (*AccountID, error)
-	UpdateStatus(ctx context.Context, in *AccountPrivileges, opts ...grpc.CallOption) (*AccountID, error)
-	GetByUID(ctx context.Context, in *AccountID, opts ...grpc.CallOption) (*Account, error)
-	Authn(ctx context.Context, in *Credentials, opts ...grpc.CallOption) (*JwtAuthTokens, error)
+func (m *IntrospectionResp) GetActive() bool {
+	if m != nil {
+		return m.Active
+	}
+	return false
 }
 
-type accountsAPIClient struct {
-	cc *grpc.ClientConn
+func (m *IntrospectionResp) GetSub() string {
+	if m != nil {
+		return m.Sub
+	}
+	return ""
 }
 
-func NewAccountsAPIClient(cc *grpc.ClientConn) AccountsAPIClient {
-	return &accountsAPIClient{cc}
+func (m *IntrospectionResp) GetExp() int64 {
+	if m != nil {
+		return m.Exp
+	}
+	return 0
 }
 
-func (c *accountsAPIClient) Create(ctx context.Context, in *AccountParams, opts ...grpc.CallOption) (*AccountID, error) {
-	out := new(AccountID)
-	err := c.cc.Invoke(ctx, "/authn.accounts.v1.AccountsAPI/Create", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *IntrospectionResp) GetIat() int64 {
+	if m != nil {
+		return m.Iat
 	}
-	return out, nil
+	return 0
 }
 
-func (c *accountsAPIClient) UpdateEmail(ctx context.Context, in *AccountParams, opts ...grpc.CallOption) (*AccountID, error) {
-	out := new(AccountID)
-	err := c.cc.Invoke(ctx, "/authn.accounts.v1.AccountsAPI/UpdateEmail", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *IntrospectionResp) GetNbf() int64 {
+	if m != nil {
+		return m.Nbf
 	}
-	return out, nil
+	return 0
 }
 
-func (c *accountsAPIClient) UpdatePassword(ctx context.Context, in *AccountParams, opts ...grpc.CallOption) (*AccountID, error) {
-	out := new(AccountID)
-	err := c.cc.Invoke(ctx, "/authn.accounts.v1.AccountsAPI/UpdatePassword", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *IntrospectionResp) GetScope() string {
+	if m != nil {
+		return m.Scope
 	}
-	return out, nil
+	return ""
 }
 
-func (c *accountsAPIClient) AddRoles(ctx context.Context, in *AccountPrivileges, opts ...grpc.CallOption) (*AccountID, error) {
-	out := new(AccountID)
-	err := c.cc.Invoke(ctx, "/authn.accounts.v1.AccountsAPI/AddRoles", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *IntrospectionResp) GetClientId() string {
+	if m != nil {
+		return m.ClientId
 	}
-	return out, nil
+	return ""
 }
 
-func (c *accountsAPIClient) RemoveRoles(ctx context.Context, in *AccountPrivileges, opts ...grpc.CallOption) (*AccountID, error) {
-	out := new(AccountID)
-	err := c.cc.Invoke(ctx, "/authn.accounts.v1.AccountsAPI/RemoveRoles", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *IntrospectionResp) GetIss() string {
+	if m != nil {
+		return m.Iss
 	}
-	return out, nil
+	return ""
 }
 
-func (c *accountsAPIClient) SetRoles(ctx context.Context, in *AccountPrivileges, opts ...grpc.CallOption) (*AccountID, error) {
-	out := new(AccountID)
-	err := c.cc.Invoke(ctx, "/authn.accounts.v1.AccountsAPI/SetRoles", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *IntrospectionResp) GetAud() []string {
+	if m != nil {
+		return m.Aud
 	}
-	return out, nil
+	return nil
 }
 
-func (c *accountsAPIClient) UpdateStatus(ctx context.Context, in *AccountPrivileges, opts ...grpc.CallOption) (*AccountID, error) {
-	out := new(AccountID)
-	err := c.cc.Invoke(ctx, "/authn.accounts.v1.AccountsAPI/UpdateStatus", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *IntrospectionResp) GetJti() string {
+	if m != nil {
+		return m.Jti
 	}
-	return out, nil
+	return ""
 }
 
-func (c *accountsAPIClient) GetByUID(ctx context.Context, in *AccountID, opts ...grpc.CallOption) (*Account, error) {
-	out := new(Account)
-	err := c.cc.Invoke(ctx, "/authn.accounts.v1.AccountsAPI/GetByUID", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *IntrospectionResp) GetTokenType() string {
+	if m != nil {
+		return m.TokenType
 	}
-	return out, nil
+	return ""
 }
 
-func (c *accountsAPIClient) Authn(ctx context.Context, in *Credentials, opts ...grpc.CallOption) (*JwtAuthTokens, error) {
-	out := new(JwtAuthTokens)
-	err := c.cc.Invoke(ctx, "/authn.accounts.v1.AccountsAPI/Authn", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *IntrospectionResp) GetType() string {
+	if m != nil {
+		return m.Type
 	}
-	return out, nil
+	return ""
 }
 
-// AccountsAPIServer is the server API for AccountsAPI service.
-type AccountsAPIServer interface {
-	Create(context.Context, *AccountParams) (*AccountID, error)
-	UpdateEmail(context.Context, *AccountParams) (*AccountID, error)
-	UpdatePassword(context.Context, *AccountParams) (*AccountID, error)
-	AddRoles(context.Context, *AccountPrivileges) (*AccountID, error)
-	RemoveRoles(context.Context, *AccountPrivileges) (*AccountID, error)
-	SetRoles(context.Context, *AccountPrivileges) (*AccountID, error)
-	UpdateStatus(context.Context, *AccountPrivileges) (*AccountID, error)
-	GetByUID(context.Context, *AccountID) (*Account, error)
-	Authn(context.Context, *Credentials) (*JwtAuthTokens, error)
+func (m *IntrospectionResp) GetStatus() AccountStatus {
+	if m != nil {
+		return m.Status
+	}
+	return AccountStatus_CREATED
 }
 
-func RegisterAccountsAPIServer(s *grpc.Server, srv AccountsAPIServer) {
-	s.RegisterService(&_AccountsAPI_serviceDesc, srv)
+func (m *IntrospectionResp) GetRoles() []string {
+	if m != nil {
+		return m.Roles
+	}
+	return nil
 }
 
-func _AccountsAPI_Create_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AccountParams)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(AccountsAPIServer).Create(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/authn.accounts.v1.AccountsAPI/Create",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(AccountsAPIServer).Create(ctx, req.(*AccountParams))
+func (m *IntrospectionResp) GetMustChangePwd() bool {
+	if m != nil {
+		return m.MustChangePwd
 	}
-	return interceptor(ctx, in, info, handler)
+	return false
 }
 
-func _AccountsAPI_UpdateEmail_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AccountParams)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(AccountsAPIServer).UpdateEmail(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/authn.accounts.v1.AccountsAPI/UpdateEmail",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(AccountsAPIServer).UpdateEmail(ctx, req.(*AccountParams))
-	}
-	return interceptor(ctx, in, info, handler)
+//TokenExchangeReq asks for a narrower access token to call downstream services on behalf of the subject of subject_token (RFC 8693)
+type TokenExchangeReq struct {
+	SubjectToken string `protobuf:"bytes,1,opt,name=subject_token,json=subjectToken,proto3" json:"subject_token,omitempty"`
+	//actor_token is the access token of the calling service. The token of the request context is used if empty
+	ActorToken string   `protobuf:"bytes,2,opt,name=actor_token,json=actorToken,proto3" json:"actor_token,omitempty"`
+	Audiences  []string `protobuf:"bytes,3,rep,name=audiences,proto3" json:"audiences,omitempty"`
+	//roles and scopes must be held by the subject token. The roles of the subject token are kept if empty
+	Roles  []string `protobuf:"bytes,4,rep,name=roles,proto3" json:"roles,omitempty"`
+	Scopes []string `protobuf:"bytes,5,rep,name=scopes,proto3" json:"scopes,omitempty"`
 }
 
-func _AccountsAPI_UpdatePassword_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AccountParams)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(AccountsAPIServer).UpdatePassword(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/authn.accounts.v1.AccountsAPI/UpdatePassword",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(AccountsAPIServer).UpdatePassword(ctx, req.(*AccountParams))
-	}
-	return interceptor(ctx, in, info, handler)
+func (m *TokenExchangeReq) Reset()         { *m = TokenExchangeReq{} }
+func (m *TokenExchangeReq) String() string { return proto.CompactTextString(m) }
+func (*TokenExchangeReq) ProtoMessage()    {}
+func (*TokenExchangeReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b32f31c7eac1477, []int{11}
 }
-
-func _AccountsAPI_AddRoles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AccountPrivileges)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(AccountsAPIServer).AddRoles(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/authn.accounts.v1.AccountsAPI/AddRoles",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(AccountsAPIServer).AddRoles(ctx, req.(*AccountPrivileges))
+func (m *TokenExchangeReq) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *TokenExchangeReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_TokenExchangeReq.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return interceptor(ctx, in, info, handler)
+}
+func (m *TokenExchangeReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TokenExchangeReq.Merge(m, src)
+}
+func (m *TokenExchangeReq) XXX_Size() int {
+	return m.Size()
+}
+func (m *TokenExchangeReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_TokenExchangeReq.DiscardUnknown(m)
 }
 
-func _AccountsAPI_RemoveRoles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AccountPrivileges)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(AccountsAPIServer).RemoveRoles(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/authn.accounts.v1.AccountsAPI/RemoveRoles",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(AccountsAPIServer).RemoveRoles(ctx, req.(*AccountPrivileges))
+var xxx_messageInfo_TokenExchangeReq proto.InternalMessageInfo
+
+func (m *TokenExchangeReq) GetSubjectToken() string {
+	if m != nil {
+		return m.SubjectToken
 	}
-	return interceptor(ctx, in, info, handler)
+	return ""
 }
 
-func _AccountsAPI_SetRoles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AccountPrivileges)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(AccountsAPIServer).SetRoles(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/authn.accounts.v1.AccountsAPI/SetRoles",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(AccountsAPIServer).SetRoles(ctx, req.(*AccountPrivileges))
+func (m *TokenExchangeReq) GetActorToken() string {
+	if m != nil {
+		return m.ActorToken
 	}
-	return interceptor(ctx, in, info, handler)
+	return ""
 }
 
-func _AccountsAPI_UpdateStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AccountPrivileges)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(AccountsAPIServer).UpdateStatus(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/authn.accounts.v1.AccountsAPI/UpdateStatus",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(AccountsAPIServer).UpdateStatus(ctx, req.(*AccountPrivileges))
+func (m *TokenExchangeReq) GetAudiences() []string {
+	if m != nil {
+		return m.Audiences
 	}
-	return interceptor(ctx, in, info, handler)
+	return nil
 }
 
-func _AccountsAPI_GetByUID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AccountID)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(AccountsAPIServer).GetByUID(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/authn.accounts.v1.AccountsAPI/GetByUID",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(AccountsAPIServer).GetByUID(ctx, req.(*AccountID))
+func (m *TokenExchangeReq) GetRoles() []string {
+	if m != nil {
+		return m.Roles
 	}
-	return interceptor(ctx, in, info, handler)
+	return nil
 }
 
-func _AccountsAPI_Authn_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Credentials)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(AccountsAPIServer).Authn(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/authn.accounts.v1.AccountsAPI/Authn",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(AccountsAPIServer).Authn(ctx, req.(*Credentials))
+func (m *TokenExchangeReq) GetScopes() []string {
+	if m != nil {
+		return m.Scopes
 	}
-	return interceptor(ctx, in, info, handler)
+	return nil
 }
 
-var _AccountsAPI_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "authn.accounts.v1.AccountsAPI",
-	HandlerType: (*AccountsAPIServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "Create",
-			Handler:    _AccountsAPI_Create_Handler,
-		},
-		{
-			MethodName: "UpdateEmail",
-			Handler:    _AccountsAPI_UpdateEmail_Handler,
-		},
-		{
-			MethodName: "UpdatePassword",
-			Handler:    _AccountsAPI_UpdatePassword_Handler,
-		},
-		{
-			MethodName: "AddRoles",
-			Handler:    _AccountsAPI_AddRoles_Handler,
-		},
-		{
-			MethodName: "RemoveRoles",
-			Handler:    _AccountsAPI_RemoveRoles_Handler,
-		},
-		{
-			MethodName: "SetRoles",
-			Handler:    _AccountsAPI_SetRoles_Handler,
-		},
-		{
-			MethodName: "UpdateStatus",
-			Handler:    _AccountsAPI_UpdateStatus_Handler,
-		},
-		{
-			MethodName: "GetByUID",
-			Handler:    _AccountsAPI_GetByUID_Handler,
-		},
-		{
-			MethodName: "Authn",
-			Handler:    _AccountsAPI_Authn_Handler,
-		},
-	},
-	Streams:  []grpc.StreamDesc{},
-	Metadata: "accounts/v1/accounts_api.proto",
+//TokenExchangeResp holds the issued token (RFC 8693)
+type TokenExchangeResp struct {
+	AccessToken     string `protobuf:"bytes,1,opt,name=access_token,proto3" json:"access_token"`
+	IssuedTokenType string `protobuf:"bytes,2,opt,name=issued_token_type,proto3" json:"issued_token_type"`
+	TokenType       string `protobuf:"bytes,3,opt,name=token_type,proto3" json:"token_type"`
+	ExpiresIn       int64  `protobuf:"varint,4,opt,name=expires_in,proto3" json:"expires_in,omitempty"`
+	Scope           string `protobuf:"bytes,5,opt,name=scope,proto3" json:"scope,omitempty"`
 }
 
-// AccountRepoClient is the client API for AccountRepo service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type AccountRepoClient interface {
-	Insert(ctx context.Context, in *Account, opts ...grpc.CallOption) (*AccountID, error)
-	Update(ctx context.Context, in *PutAccountParams, opts ...grpc.CallOption) (*AccountID, error)
-	Get(ctx context.Context, in *AccountID, opts ...grpc.CallOption) (*Account, error)
-	GetMulti(ctx context.Context, in *AccountIDs, opts ...grpc.CallOption) (*MultiAccounts, error)
-	Delete(ctx context.Context, in *AccountID, opts ...grpc.CallOption) (*AccountID, error)
-	DeleteMulti(ctx context.Context, in *AccountIDs, opts ...grpc.CallOption) (*AccountIDs, error)
+func (m *TokenExchangeResp) Reset()         { *m = TokenExchangeResp{} }
+func (m *TokenExchangeResp) String() string { return proto.CompactTextString(m) }
+func (*TokenExchangeResp) ProtoMessage()    {}
+func (*TokenExchangeResp) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b32f31c7eac1477, []int{12}
 }
-
-type accountRepoClient struct {
-	cc *grpc.ClientConn
+func (m *TokenExchangeResp) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
 }
-
-func NewAccountRepoClient(cc *grpc.ClientConn) AccountRepoClient {
-	return &accountRepoClient{cc}
+func (m *TokenExchangeResp) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_TokenExchangeResp.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *TokenExchangeResp) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TokenExchangeResp.Merge(m, src)
+}
+func (m *TokenExchangeResp) XXX_Size() int {
+	return m.Size()
+}
+func (m *TokenExchangeResp) XXX_DiscardUnknown() {
+	xxx_messageInfo_TokenExchangeResp.DiscardUnknown(m)
 }
 
-func (c *accountRepoClient) Insert(ctx context.Context, in *Account, opts ...grpc.CallOption) (*AccountID, error) {
-	out := new(AccountID)
-	err := c.cc.Invoke(ctx, "/authn.accounts.v1.AccountRepo/Insert", in, out, opts...)
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_TokenExchangeResp proto.InternalMessageInfo
+
+func (m *TokenExchangeResp) GetAccessToken() string {
+	if m != nil {
+		return m.AccessToken
 	}
-	return out, nil
+	return ""
 }
 
-func (c *accountRepoClient) Update(ctx context.Context, in *PutAccountParams, opts ...grpc.CallOption) (*AccountID, error) {
-	out := new(AccountID)
-	err := c.cc.Invoke(ctx, "/authn.accounts.v1.AccountRepo/Update", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *TokenExchangeResp) GetIssuedTokenType() string {
+	if m != nil {
+		return m.IssuedTokenType
 	}
-	return out, nil
+	return ""
 }
 
-func (c *accountRepoClient) Get(ctx context.Context, in *AccountID, opts ...grpc.CallOption) (*Account, error) {
-	out := new(Account)
-	err := c.cc.Invoke(ctx, "/authn.accounts.v1.AccountRepo/Get", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *TokenExchangeResp) GetTokenType() string {
+	if m != nil {
+		return m.TokenType
 	}
-	return out, nil
+	return ""
 }
 
-func (c *accountRepoClient) GetMulti(ctx context.Context, in *AccountIDs, opts ...grpc.CallOption) (*MultiAccounts, error) {
-	out := new(MultiAccounts)
-	err := c.cc.Invoke(ctx, "/authn.accounts.v1.AccountRepo/GetMulti", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *TokenExchangeResp) GetExpiresIn() int64 {
+	if m != nil {
+		return m.ExpiresIn
 	}
-	return out, nil
+	return 0
 }
 
-func (c *accountRepoClient) Delete(ctx context.Context, in *AccountID, opts ...grpc.CallOption) (*AccountID, error) {
-	out := new(AccountID)
-	err := c.cc.Invoke(ctx, "/authn.accounts.v1.AccountRepo/Delete", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *TokenExchangeResp) GetScope() string {
+	if m != nil {
+		return m.Scope
 	}
-	return out, nil
+	return ""
 }
 
-func (c *accountRepoClient) DeleteMulti(ctx context.Context, in *AccountIDs, opts ...grpc.CallOption) (*AccountIDs, error) {
-	out := new(AccountIDs)
-	err := c.cc.Invoke(ctx, "/authn.accounts.v1.AccountRepo/DeleteMulti", in, out, opts...)
-	if err != nil {
-		return nil, err
+//PasswordResetReq asks for a password reset link sent to the email of an account
+type PasswordResetReq struct {
+	Email string `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+}
+
+func (m *PasswordResetReq) Reset()         { *m = PasswordResetReq{} }
+func (m *PasswordResetReq) String() string { return proto.CompactTextString(m) }
+func (*PasswordResetReq) ProtoMessage()    {}
+func (*PasswordResetReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b32f31c7eac1477, []int{13}
+}
+func (m *PasswordResetReq) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PasswordResetReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PasswordResetReq.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return out, nil
+}
+func (m *PasswordResetReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PasswordResetReq.Merge(m, src)
+}
+func (m *PasswordResetReq) XXX_Size() int {
+	return m.Size()
+}
+func (m *PasswordResetReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_PasswordResetReq.DiscardUnknown(m)
 }
 
-// AccountRepoServer is the server API for AccountRepo service.
-type AccountRepoServer interface {
-	Insert(context.Context, *Account) (*AccountID, error)
-	Update(context.Context, *PutAccountParams) (*AccountID, error)
-	Get(context.Context, *AccountID) (*Account, error)
-	GetMulti(context.Context, *AccountIDs) (*MultiAccounts, error)
-	Delete(context.Context, *AccountID) (*AccountID, error)
-	DeleteMulti(context.Context, *AccountIDs) (*AccountIDs, error)
+var xxx_messageInfo_PasswordResetReq proto.InternalMessageInfo
+
+func (m *PasswordResetReq) GetEmail() string {
+	if m != nil {
+		return m.Email
+	}
+	return ""
 }
 
-func RegisterAccountRepoServer(s *grpc.Server, srv AccountRepoServer) {
-	s.RegisterService(&_AccountRepo_serviceDesc, srv)
+//CompletePasswordResetReq sets a new password with a reset token
+type CompletePasswordResetReq struct {
+	Token string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	Pwd   string `protobuf:"bytes,2,opt,name=pwd,proto3" json:"pwd,omitempty"`
 }
 
-func _AccountRepo_Insert_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Account)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(AccountRepoServer).Insert(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/authn.accounts.v1.AccountRepo/Insert",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(AccountRepoServer).Insert(ctx, req.(*Account))
+func (m *CompletePasswordResetReq) Reset()         { *m = CompletePasswordResetReq{} }
+func (m *CompletePasswordResetReq) String() string { return proto.CompactTextString(m) }
+func (*CompletePasswordResetReq) ProtoMessage()    {}
+func (*CompletePasswordResetReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b32f31c7eac1477, []int{14}
+}
+func (m *CompletePasswordResetReq) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *CompletePasswordResetReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_CompletePasswordResetReq.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return interceptor(ctx, in, info, handler)
+}
+func (m *CompletePasswordResetReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CompletePasswordResetReq.Merge(m, src)
+}
+func (m *CompletePasswordResetReq) XXX_Size() int {
+	return m.Size()
+}
+func (m *CompletePasswordResetReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_CompletePasswordResetReq.DiscardUnknown(m)
 }
 
-func _AccountRepo_Update_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(PutAccountParams)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(AccountRepoServer).Update(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/authn.accounts.v1.AccountRepo/Update",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(AccountRepoServer).Update(ctx, req.(*PutAccountParams))
+var xxx_messageInfo_CompletePasswordResetReq proto.InternalMessageInfo
+
+func (m *CompletePasswordResetReq) GetToken() string {
+	if m != nil {
+		return m.Token
 	}
-	return interceptor(ctx, in, info, handler)
+	return ""
 }
 
-func _AccountRepo_Get_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AccountID)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(AccountRepoServer).Get(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/authn.accounts.v1.AccountRepo/Get",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(AccountRepoServer).Get(ctx, req.(*AccountID))
+func (m *CompletePasswordResetReq) GetPwd() string {
+	if m != nil {
+		return m.Pwd
 	}
-	return interceptor(ctx, in, info, handler)
+	return ""
 }
 
-func _AccountRepo_GetMulti_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AccountIDs)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(AccountRepoServer).GetMulti(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/authn.accounts.v1.AccountRepo/GetMulti",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(AccountRepoServer).GetMulti(ctx, req.(*AccountIDs))
-	}
-	return interceptor(ctx, in, info, handler)
+//VerifyEmailReq confirms an email with the code sent to it
+type VerifyEmailReq struct {
+	Code string `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
 }
 
-func _AccountRepo_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AccountID)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(AccountRepoServer).Delete(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/authn.accounts.v1.AccountRepo/Delete",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(AccountRepoServer).Delete(ctx, req.(*AccountID))
+func (m *VerifyEmailReq) Reset()         { *m = VerifyEmailReq{} }
+func (m *VerifyEmailReq) String() string { return proto.CompactTextString(m) }
+func (*VerifyEmailReq) ProtoMessage()    {}
+func (*VerifyEmailReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b32f31c7eac1477, []int{15}
+}
+func (m *VerifyEmailReq) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *VerifyEmailReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_VerifyEmailReq.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return interceptor(ctx, in, info, handler)
+}
+func (m *VerifyEmailReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_VerifyEmailReq.Merge(m, src)
+}
+func (m *VerifyEmailReq) XXX_Size() int {
+	return m.Size()
+}
+func (m *VerifyEmailReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_VerifyEmailReq.DiscardUnknown(m)
 }
 
-func _AccountRepo_DeleteMulti_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AccountIDs)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(AccountRepoServer).DeleteMulti(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/authn.accounts.v1.AccountRepo/DeleteMulti",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(AccountRepoServer).DeleteMulti(ctx, req.(*AccountIDs))
+var xxx_messageInfo_VerifyEmailReq proto.InternalMessageInfo
+
+func (m *VerifyEmailReq) GetCode() string {
+	if m != nil {
+		return m.Code
 	}
-	return interceptor(ctx, in, info, handler)
+	return ""
 }
 
-var _AccountRepo_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "authn.accounts.v1.AccountRepo",
-	HandlerType: (*AccountRepoServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "Insert",
-			Handler:    _AccountRepo_Insert_Handler,
-		},
-		{
-			MethodName: "Update",
-			Handler:    _AccountRepo_Update_Handler,
-		},
-		{
-			MethodName: "Get",
-			Handler:    _AccountRepo_Get_Handler,
-		},
-		{
-			MethodName: "GetMulti",
-			Handler:    _AccountRepo_GetMulti_Handler,
-		},
-		{
-			MethodName: "Delete",
-			Handler:    _AccountRepo_Delete_Handler,
-		},
-		{
-			MethodName: "DeleteMulti",
-			Handler:    _AccountRepo_DeleteMulti_Handler,
-		},
-	},
-	Streams:  []grpc.StreamDesc{},
-	Metadata: "accounts/v1/accounts_api.proto",
+type PutAccountParams struct {
+	Uid  string   `protobuf:"bytes,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	Acct *Account `protobuf:"bytes,2,opt,name=acct,proto3" json:"acct,omitempty"`
 }
 
-func (m *Account) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (m *PutAccountParams) Reset()         { *m = PutAccountParams{} }
+func (m *PutAccountParams) String() string { return proto.CompactTextString(m) }
+func (*PutAccountParams) ProtoMessage()    {}
+func (*PutAccountParams) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b32f31c7eac1477, []int{16}
+}
+func (m *PutAccountParams) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PutAccountParams) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PutAccountParams.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return dAtA[:n], nil
+}
+func (m *PutAccountParams) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PutAccountParams.Merge(m, src)
+}
+func (m *PutAccountParams) XXX_Size() int {
+	return m.Size()
+}
+func (m *PutAccountParams) XXX_DiscardUnknown() {
+	xxx_messageInfo_PutAccountParams.DiscardUnknown(m)
 }
 
-func (m *Account) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.Uid) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintAccountsApi(dAtA, i, uint64(len(m.Uid)))
-		i += copy(dAtA[i:], m.Uid)
-	}
-	if len(m.Email) > 0 {
-		dAtA[i] = 0x1a
-		i++
-		i = encodeVarintAccountsApi(dAtA, i, uint64(len(m.Email)))
-		i += copy(dAtA[i:], m.Email)
+var xxx_messageInfo_PutAccountParams proto.InternalMessageInfo
+
+func (m *PutAccountParams) GetUid() string {
+	if m != nil {
+		return m.Uid
 	}
-	if len(m.Hash) > 0 {
-		dAtA[i] = 0x22
-		i++
-		i = encodeVarintAccountsApi(dAtA, i, uint64(len(m.Hash)))
-		i += copy(dAtA[i:], m.Hash)
-	}
-	if m.CreatedAt != 0 {
-		dAtA[i] = 0x28
-		i++
-		i = encodeVarintAccountsApi(dAtA, i, uint64(m.CreatedAt))
-	}
-	if m.UpdatedAt != 0 {
-		dAtA[i] = 0x30
-		i++
-		i = encodeVarintAccountsApi(dAtA, i, uint64(m.UpdatedAt))
-	}
-	if len(m.Roles) > 0 {
-		for _, s := range m.Roles {
-			dAtA[i] = 0x3a
-			i++
-			l = len(s)
-			for l >= 1<<7 {
-				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
-				l >>= 7
-				i++
-			}
-			dAtA[i] = uint8(l)
-			i++
-			i += copy(dAtA[i:], s)
-		}
+	return ""
+}
+
+func (m *PutAccountParams) GetAcct() *Account {
+	if m != nil {
+		return m.Acct
 	}
-	if m.Status != 0 {
-		dAtA[i] = 0x40
-		i++
-		i = encodeVarintAccountsApi(dAtA, i, uint64(m.Status))
+	return nil
+}
+
+func init() {
+	proto.RegisterEnum("authn.accounts.v1.AccountStatus", AccountStatus_name, AccountStatus_value)
+	proto.RegisterEnum("authn.accounts.v1.IDType", IDType_name, IDType_value)
+	proto.RegisterType((*Account)(nil), "authn.accounts.v1.Account")
+	proto.RegisterType((*Info)(nil), "authn.accounts.v1.Info")
+	proto.RegisterType((*MultiAccounts)(nil), "authn.accounts.v1.MultiAccounts")
+	proto.RegisterType((*AccountID)(nil), "authn.accounts.v1.AccountID")
+	proto.RegisterType((*AccountIDs)(nil), "authn.accounts.v1.AccountIDs")
+	proto.RegisterType((*AccountParams)(nil), "authn.accounts.v1.AccountParams")
+	proto.RegisterType((*AccountPrivileges)(nil), "authn.accounts.v1.AccountPrivileges")
+	proto.RegisterType((*JwtAuthTokens)(nil), "authn.accounts.v1.JwtAuthTokens")
+	proto.RegisterType((*Credentials)(nil), "authn.accounts.v1.Credentials")
+	proto.RegisterType((*IntrospectionReq)(nil), "authn.accounts.v1.IntrospectionReq")
+	proto.RegisterType((*IntrospectionResp)(nil), "authn.accounts.v1.IntrospectionResp")
+	proto.RegisterType((*TokenExchangeReq)(nil), "authn.accounts.v1.TokenExchangeReq")
+	proto.RegisterType((*TokenExchangeResp)(nil), "authn.accounts.v1.TokenExchangeResp")
+	proto.RegisterType((*PasswordResetReq)(nil), "authn.accounts.v1.PasswordResetReq")
+	proto.RegisterType((*CompletePasswordResetReq)(nil), "authn.accounts.v1.CompletePasswordResetReq")
+	proto.RegisterType((*VerifyEmailReq)(nil), "authn.accounts.v1.VerifyEmailReq")
+	proto.RegisterType((*PutAccountParams)(nil), "authn.accounts.v1.PutAccountParams")
+}
+
+func init() { proto.RegisterFile("accounts/v1/accounts_api.proto", fileDescriptor_3b32f31c7eac1477) }
+
+var fileDescriptor_3b32f31c7eac1477 = []byte{
+	// 1826 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x58, 0xcd, 0x6f, 0xdb, 0xc8,
+	0x15, 0x0f, 0x45, 0x49, 0x96, 0x9e, 0x3e, 0x22, 0x4f, 0xbc, 0x5b, 0xc6, 0x9b, 0x98, 0x2a, 0x9d,
+	0x2e, 0x9c, 0xed, 0xda, 0x46, 0xbc, 0x5d, 0x20, 0x2d, 0x0a, 0x14, 0x96, 0x25, 0x24, 0xda, 0xd8,
+	0x89, 0x97, 0x76, 0xb6, 0xe8, 0x5e, 0x54, 0x8a, 0x1c, 0x59, 0x4c, 0x64, 0x92, 0xcb, 0x19, 0xfa,
+	0xe3, 0xbf, 0x28, 0x7a, 0xec, 0xb5, 0x3d, 0xf7, 0xdc, 0x4b, 0xd1, 0x6b, 0x8f, 0x7b, 0xec, 0x89,
+	0x28, 0x92, 0x4b, 0xa1, 0xa3, 0xff, 0x82, 0x62, 0x3e, 0x28, 0x51, 0xb2, 0x22, 0x79, 0xe1, 0x9c,
+	0x34, 0xef, 0x37, 0xbf, 0x79, 0xf3, 0xe6, 0xcd, 0xfb, 0x18, 0x11, 0xd6, 0x2c, 0xdb, 0xf6, 0x23,
+	0x8f, 0x92, 0xed, 0xb3, 0x27, 0xdb, 0xc9, 0xb8, 0x63, 0x05, 0xee, 0x56, 0x10, 0xfa, 0xd4, 0x47,
+	0xcb, 0x56, 0x44, 0xfb, 0xde, 0x56, 0x32, 0xb3, 0x75, 0xf6, 0x64, 0x75, 0xf3, 0xc4, 0xa5, 0xfd,
+	0xa8, 0xbb, 0x65, 0xfb, 0xa7, 0xdb, 0x27, 0xfe, 0x89, 0xbf, 0xcd, 0x99, 0xdd, 0xa8, 0xc7, 0x25,
+	0x2e, 0xf0, 0x91, 0xd0, 0x60, 0xfc, 0x2f, 0x0b, 0x4b, 0xbb, 0x62, 0x39, 0xfa, 0x05, 0xa8, 0x91,
+	0xeb, 0x68, 0x4a, 0x5d, 0xd9, 0x28, 0x36, 0xee, 0x0d, 0x63, 0x9d, 0x89, 0x57, 0xb1, 0x5e, 0x70,
+	0xba, 0xbf, 0x31, 0x22, 0xd7, 0x31, 0x4c, 0x06, 0xa0, 0x4d, 0xc8, 0xe1, 0x53, 0xcb, 0x1d, 0x68,
+	0x2a, 0x27, 0xfe, 0x6c, 0x18, 0xeb, 0x02, 0xb8, 0x8a, 0x75, 0x60, 0x54, 0x2e, 0x18, 0xa6, 0x00,
+	0xd1, 0x3a, 0x64, 0xfb, 0x16, 0xe9, 0x6b, 0x59, 0xce, 0x46, 0xc3, 0x58, 0x57, 0x36, 0xaf, 0x62,
+	0xbd, 0xc8, 0x98, 0x6c, 0xc2, 0x30, 0x95, 0x4d, 0xb4, 0x0d, 0x60, 0x87, 0xd8, 0xa2, 0xd8, 0xe9,
+	0x58, 0x54, 0xcb, 0xd5, 0x95, 0x0d, 0xb5, 0xf1, 0xc9, 0x30, 0xd6, 0xb3, 0x0c, 0x4d, 0xd8, 0x6c,
+	0x6c, 0x98, 0x1c, 0x42, 0x5f, 0x02, 0x44, 0x81, 0x93, 0x2c, 0xc8, 0xf3, 0x05, 0xc2, 0xe4, 0x60,
+	0x6c, 0x72, 0xc0, 0x4d, 0x0e, 0xb8, 0xc9, 0xa1, 0x3f, 0xc0, 0x44, 0x5b, 0xaa, 0xab, 0x89, 0xc9,
+	0x1c, 0x48, 0x4c, 0xe6, 0x82, 0x61, 0x0a, 0x10, 0x1d, 0x41, 0x9e, 0x50, 0x8b, 0x46, 0x44, 0x2b,
+	0xd4, 0x95, 0x8d, 0xea, 0x4e, 0x7d, 0xeb, 0x9a, 0x9f, 0xb7, 0xa4, 0xd3, 0x8e, 0x38, 0xaf, 0x71,
+	0x7f, 0x18, 0xeb, 0x72, 0xcd, 0x55, 0xac, 0x97, 0x98, 0x4a, 0x21, 0x19, 0xa6, 0x84, 0xd1, 0xaf,
+	0xa1, 0x1a, 0x58, 0x21, 0xf6, 0x68, 0x47, 0xaa, 0xd1, 0x8a, 0xdc, 0x23, 0x7c, 0xa9, 0x98, 0x49,
+	0x96, 0x0a, 0xc9, 0x30, 0x25, 0x8c, 0xbe, 0x82, 0x32, 0xf3, 0x54, 0xa7, 0xef, 0x12, 0xea, 0x87,
+	0x97, 0x1a, 0xf0, 0x53, 0xac, 0x26, 0xae, 0x5c, 0x4e, 0x5c, 0x99, 0x10, 0xb8, 0x4b, 0x7f, 0x0b,
+	0xd5, 0xe0, 0xdc, 0xe9, 0xd8, 0x7d, 0xcb, 0x3b, 0x11, 0x5e, 0x2a, 0x71, 0x2f, 0x7d, 0x36, 0x8c,
+	0xf5, 0x25, 0x36, 0x23, 0x3c, 0x55, 0xe6, 0x1b, 0x0a, 0xd1, 0x30, 0x93, 0x09, 0xf4, 0x2d, 0x54,
+	0xf9, 0xf5, 0x75, 0xce, 0x70, 0xe8, 0xf6, 0x5c, 0xec, 0x68, 0xe5, 0xba, 0xb2, 0x51, 0x68, 0x3c,
+	0x1e, 0xc6, 0xfa, 0xd4, 0xcc, 0x55, 0xac, 0xdf, 0x1b, 0x5d, 0xfb, 0x08, 0x35, 0xcc, 0x29, 0x9a,
+	0xf1, 0xcf, 0x0c, 0x64, 0xdb, 0x5e, 0xcf, 0x47, 0x8f, 0x21, 0x4b, 0x2f, 0x03, 0x2c, 0x03, 0x8d,
+	0x5f, 0x33, 0x93, 0x93, 0x6b, 0x66, 0x63, 0xc3, 0xe4, 0x50, 0x12, 0x92, 0x99, 0x05, 0x21, 0x39,
+	0xbe, 0x30, 0xf5, 0xe3, 0x5d, 0xd8, 0x28, 0x68, 0xb2, 0x37, 0x0a, 0x9a, 0x0e, 0xdc, 0x3d, 0x8d,
+	0x08, 0x95, 0x0e, 0xef, 0x04, 0xe7, 0x0e, 0x8f, 0xe3, 0x42, 0xe3, 0xeb, 0x61, 0xac, 0xdf, 0x9f,
+	0x9a, 0xfa, 0xd2, 0x3f, 0x75, 0x29, 0x3e, 0x0d, 0xe8, 0xe5, 0x55, 0xac, 0xaf, 0x30, 0x65, 0x53,
+	0x04, 0xc3, 0x9c, 0xd6, 0x66, 0xf4, 0xa0, 0x72, 0x10, 0x0d, 0xa8, 0x2b, 0x0f, 0x42, 0xd0, 0x6b,
+	0x28, 0x24, 0x07, 0xd4, 0x94, 0xba, 0xba, 0x51, 0xda, 0x59, 0xfd, 0xf0, 0xb9, 0x1b, 0x0f, 0x87,
+	0xb1, 0x3e, 0xe2, 0x5f, 0xc5, 0x7a, 0x85, 0xed, 0x9a, 0xc8, 0x86, 0x39, 0x9a, 0x32, 0xbe, 0x81,
+	0xa2, 0x5c, 0xd3, 0x6e, 0xa2, 0x2a, 0x64, 0x92, 0x92, 0x60, 0x66, 0x78, 0xf2, 0x8b, 0xbb, 0xcb,
+	0x70, 0x3f, 0xdf, 0x9f, 0xb1, 0x5f, 0xbb, 0x79, 0x7c, 0x19, 0x60, 0x71, 0x7f, 0xc6, 0x01, 0xc0,
+	0x48, 0x17, 0x41, 0x35, 0x50, 0x5d, 0x47, 0xd8, 0x5a, 0x34, 0xd9, 0xf0, 0xa7, 0xaa, 0xb3, 0xa0,
+	0x22, 0xd5, 0x1d, 0x5a, 0xa1, 0x75, 0xca, 0x35, 0x8e, 0x4a, 0x96, 0x08, 0x85, 0x95, 0xa4, 0x3a,
+	0xf1, 0x98, 0x49, 0x8a, 0x50, 0x0d, 0x54, 0x76, 0x21, 0xaa, 0xe0, 0x05, 0xe7, 0x0e, 0xfa, 0x14,
+	0x64, 0x76, 0x89, 0xc2, 0x94, 0xe4, 0x9a, 0x11, 0xc1, 0x72, 0xb2, 0x45, 0xe8, 0x9e, 0xb9, 0x03,
+	0x7c, 0x82, 0x3f, 0xb0, 0x8d, 0x08, 0x8e, 0x0c, 0x3f, 0x8c, 0x10, 0xd0, 0xd3, 0x9f, 0x1a, 0x87,
+	0x49, 0xb0, 0x19, 0x7f, 0x84, 0xca, 0x37, 0xe7, 0x74, 0x37, 0xa2, 0xfd, 0x63, 0xff, 0x2d, 0xf6,
+	0x08, 0xb3, 0xcf, 0xb2, 0x6d, 0x4c, 0x88, 0xdc, 0x55, 0x4a, 0x48, 0x83, 0xa5, 0x10, 0xf7, 0x42,
+	0x4c, 0xfa, 0xf2, 0x84, 0x89, 0x88, 0x1e, 0x02, 0x38, 0x81, 0x1f, 0x74, 0x82, 0xd0, 0xf7, 0x7b,
+	0xf2, 0xa8, 0x45, 0x86, 0x1c, 0x32, 0xc0, 0xf8, 0xbb, 0x02, 0xa5, 0xbd, 0x10, 0x3b, 0xd8, 0xa3,
+	0xae, 0x35, 0x20, 0xd7, 0x6e, 0x56, 0xba, 0x28, 0x33, 0x76, 0xd1, 0x03, 0x28, 0x5a, 0x91, 0xe3,
+	0x62, 0xcf, 0xc6, 0xec, 0x40, 0xec, 0x9c, 0x63, 0x60, 0x6a, 0xbb, 0xec, 0xd4, 0x76, 0xe8, 0x33,
+	0x28, 0xda, 0x03, 0x97, 0x95, 0x3b, 0x57, 0x24, 0x42, 0xd1, 0x2c, 0x08, 0xa0, 0xed, 0xa0, 0x75,
+	0xa8, 0xc8, 0x49, 0x82, 0xed, 0x10, 0x8b, 0x02, 0x5e, 0x34, 0xcb, 0x02, 0x3c, 0xe2, 0x98, 0xf1,
+	0x67, 0x05, 0x6a, 0x6d, 0x8f, 0x86, 0x3e, 0x09, 0xb0, 0x4d, 0x5d, 0xdf, 0x33, 0xf1, 0x0f, 0xcc,
+	0xef, 0x94, 0x39, 0x48, 0x1a, 0x2e, 0x04, 0xf4, 0x39, 0xdc, 0xe5, 0x83, 0x0e, 0x8b, 0x92, 0x4e,
+	0xdf, 0xf5, 0xa8, 0x3c, 0x47, 0x85, 0xc3, 0x2c, 0x82, 0x9e, 0xbb, 0x1e, 0x9d, 0x34, 0x4a, 0x5d,
+	0x64, 0x54, 0x76, 0x86, 0x51, 0xff, 0xc8, 0xc1, 0xf2, 0x94, 0x51, 0x24, 0x40, 0x06, 0xbb, 0x2c,
+	0xea, 0x9e, 0x89, 0x9a, 0x56, 0x68, 0x00, 0xab, 0x2e, 0x02, 0x31, 0xe5, 0x2f, 0x5a, 0x07, 0x95,
+	0x44, 0x5d, 0x59, 0xca, 0x96, 0x87, 0xb1, 0x5e, 0x21, 0x51, 0x77, 0x5c, 0x07, 0x4c, 0x36, 0xcb,
+	0x48, 0xf8, 0x22, 0xe0, 0xa6, 0xa9, 0x82, 0x84, 0x2f, 0x82, 0x34, 0x09, 0x5f, 0x04, 0x8c, 0xe4,
+	0x5a, 0xc2, 0x3c, 0x49, 0x72, 0x2d, 0x9a, 0x26, 0xb9, 0x16, 0x65, 0x24, 0xaf, 0xdb, 0xd3, 0x72,
+	0x63, 0x92, 0xd7, 0xed, 0xa5, 0x49, 0x5e, 0xb7, 0x87, 0x1e, 0x43, 0x8e, 0xd8, 0x7e, 0x80, 0xb5,
+	0xfc, 0xa8, 0xc0, 0xde, 0xe5, 0x40, 0x8a, 0x28, 0x18, 0xe8, 0xeb, 0xb4, 0xeb, 0x96, 0x46, 0x9d,
+	0xff, 0xde, 0x08, 0x4c, 0x2d, 0x19, 0x33, 0xb9, 0xad, 0x44, 0xf4, 0x51, 0x79, 0x6a, 0x97, 0x90,
+	0x09, 0x5b, 0x09, 0x61, 0x24, 0x2b, 0x72, 0xb4, 0x62, 0x5d, 0x4d, 0x48, 0x56, 0x94, 0xd6, 0xc7,
+	0x66, 0x19, 0xe9, 0x0d, 0x75, 0x35, 0x18, 0x6b, 0x7a, 0x43, 0xdd, 0x34, 0xe9, 0x0d, 0x75, 0xd1,
+	0x53, 0x80, 0x71, 0x20, 0xf0, 0x86, 0x57, 0x6c, 0x68, 0xc3, 0x58, 0x5f, 0x19, 0xa3, 0xa9, 0x25,
+	0x29, 0x2e, 0xfa, 0x5c, 0x56, 0xa2, 0xf2, 0xe8, 0x99, 0x52, 0x9d, 0x62, 0xf3, 0x79, 0xd4, 0x1c,
+	0xa5, 0x78, 0xe5, 0x86, 0xad, 0x06, 0xc6, 0xad, 0x66, 0xd4, 0x5b, 0x1e, 0x27, 0xe5, 0xa3, 0x5a,
+	0x57, 0x13, 0xc7, 0x73, 0x20, 0xed, 0x78, 0x0e, 0xa0, 0x67, 0xd7, 0xfb, 0xca, 0x5d, 0x1e, 0x64,
+	0x0f, 0xe7, 0xf6, 0x95, 0xeb, 0xfd, 0xe3, 0x6f, 0x0a, 0xd4, 0x78, 0x71, 0x69, 0x5d, 0x08, 0x94,
+	0xe5, 0xd3, 0x3a, 0xb0, 0x30, 0x7c, 0x83, 0x6d, 0xda, 0x49, 0xe7, 0x55, 0x59, 0x82, 0x9c, 0x8f,
+	0x74, 0x28, 0x59, 0x36, 0xf5, 0x43, 0x49, 0x11, 0xa9, 0x05, 0x1c, 0x12, 0x84, 0xf9, 0x95, 0x62,
+	0x65, 0xa2, 0x91, 0x26, 0xe7, 0xfa, 0x14, 0xf2, 0x3c, 0xb2, 0x88, 0x96, 0xe3, 0xb0, 0x94, 0x8c,
+	0xbf, 0x66, 0x60, 0x79, 0xca, 0x4c, 0x12, 0xa0, 0x5f, 0x41, 0x59, 0x14, 0xc0, 0xb4, 0x99, 0x8d,
+	0xda, 0x30, 0xd6, 0x27, 0x70, 0x73, 0x42, 0x42, 0x7b, 0xb0, 0xec, 0x12, 0x12, 0x61, 0xa7, 0x93,
+	0x8a, 0x8a, 0xcc, 0xe8, 0xd9, 0x71, 0x7d, 0xd2, 0xbc, 0x0e, 0xa1, 0xad, 0x89, 0x98, 0x12, 0x8f,
+	0xde, 0xea, 0x30, 0xd6, 0x53, 0xe8, 0x44, 0x24, 0x3d, 0x05, 0xc0, 0x17, 0x81, 0x1b, 0x62, 0xd2,
+	0x71, 0x3d, 0x99, 0xa5, 0x3c, 0x06, 0xc7, 0x68, 0x3a, 0x06, 0xc7, 0xe8, 0x38, 0x1d, 0x73, 0x8b,
+	0xd2, 0xd1, 0xd8, 0x80, 0xda, 0xa1, 0x45, 0xc8, 0xb9, 0x1f, 0x3a, 0x26, 0x26, 0x98, 0xca, 0xda,
+	0x28, 0x5a, 0x9f, 0x92, 0x6a, 0x7d, 0x46, 0x03, 0xb4, 0x3d, 0xff, 0x34, 0x18, 0x60, 0x8a, 0x67,
+	0xad, 0x98, 0x51, 0x4d, 0xaf, 0x75, 0x02, 0xe3, 0x11, 0x54, 0xbf, 0x63, 0xcf, 0xb8, 0xcb, 0x16,
+	0x53, 0xc9, 0x56, 0x22, 0xc8, 0xda, 0xbe, 0x23, 0xdf, 0x70, 0x26, 0x1f, 0x1b, 0xc7, 0x50, 0x3b,
+	0x8c, 0xe8, 0xa2, 0x06, 0xbd, 0x05, 0x59, 0xcb, 0xb6, 0x45, 0x81, 0x9e, 0xfb, 0x62, 0x31, 0x39,
+	0xef, 0x8b, 0x57, 0x50, 0x99, 0xc8, 0x27, 0x54, 0x82, 0xa5, 0x3d, 0xb3, 0xb5, 0x7b, 0xdc, 0x6a,
+	0xd6, 0xee, 0x20, 0x80, 0xfc, 0xee, 0xde, 0x71, 0xfb, 0xbb, 0x56, 0x4d, 0x61, 0xe3, 0xfd, 0x57,
+	0x7b, 0x2f, 0x5a, 0xcd, 0x5a, 0x06, 0x95, 0xa1, 0xd0, 0x7e, 0x29, 0x67, 0x54, 0xb6, 0xa4, 0xd9,
+	0xda, 0x6f, 0xb1, 0x25, 0xd9, 0x2f, 0x1e, 0x40, 0x5e, 0x3c, 0x2a, 0xd0, 0x12, 0xa8, 0xaf, 0xdb,
+	0x4c, 0x4b, 0x11, 0x72, 0xad, 0x83, 0xdd, 0xf6, 0x7e, 0x4d, 0xd9, 0xf9, 0x4b, 0x19, 0x4a, 0x72,
+	0x3f, 0xb2, 0x7b, 0xd8, 0x46, 0xcf, 0x21, 0xbf, 0xc7, 0xff, 0x99, 0xa0, 0x39, 0x99, 0x2e, 0x0e,
+	0xbb, 0xfa, 0xe0, 0xc3, 0x8c, 0x76, 0x13, 0x1d, 0x40, 0xe9, 0x35, 0xff, 0xcb, 0xc2, 0x9d, 0x78,
+	0x6b, 0x75, 0x87, 0x50, 0x15, 0xea, 0x92, 0x5b, 0xbd, 0xb5, 0xc6, 0x97, 0x50, 0xd8, 0x75, 0x1c,
+	0x93, 0x67, 0xe7, 0xa3, 0x39, 0xba, 0x46, 0xef, 0xa2, 0x05, 0xfa, 0xbe, 0x85, 0x92, 0x89, 0x4f,
+	0xfd, 0x33, 0xfc, 0xf1, 0x54, 0xbe, 0x84, 0xc2, 0x11, 0xa6, 0x1f, 0x4f, 0x9f, 0x09, 0x65, 0xe1,
+	0x44, 0x19, 0x5b, 0x1f, 0x43, 0x67, 0x13, 0x0a, 0xcf, 0x30, 0x6d, 0x5c, 0xbe, 0x6e, 0x37, 0xd1,
+	0x5c, 0xe6, 0xea, 0x9c, 0xe0, 0x47, 0x6d, 0xc8, 0xb1, 0xd7, 0xa0, 0x87, 0xd6, 0x66, 0x90, 0x52,
+	0xef, 0xb8, 0xd5, 0x59, 0xb7, 0x3e, 0xf9, 0x94, 0x3c, 0x80, 0x25, 0x53, 0xbe, 0x11, 0x17, 0x92,
+	0x6f, 0xa0, 0xee, 0x39, 0xe4, 0xf7, 0xfd, 0x13, 0x3f, 0xa2, 0x37, 0xd0, 0x36, 0xdf, 0x53, 0xaf,
+	0x60, 0xd9, 0xc4, 0x67, 0xfe, 0x5b, 0xbc, 0x3b, 0x18, 0x1c, 0x61, 0x42, 0x5c, 0xdf, 0x23, 0x0b,
+	0x5c, 0x36, 0x5f, 0xe1, 0xef, 0x01, 0xc6, 0x8f, 0x33, 0xb4, 0x3e, 0xeb, 0xef, 0xc4, 0xd4, 0x83,
+	0x72, 0xf5, 0xd1, 0x62, 0x12, 0x09, 0xd0, 0xf7, 0x50, 0x49, 0xda, 0x91, 0xe8, 0x78, 0xb3, 0x74,
+	0x4f, 0x37, 0xd7, 0xd5, 0x47, 0x8b, 0x49, 0x24, 0x40, 0x7f, 0x80, 0x15, 0x13, 0xff, 0x10, 0x61,
+	0x42, 0x27, 0xea, 0xf3, 0xcc, 0x2d, 0xa6, 0x2b, 0xf8, 0x02, 0x7f, 0x74, 0xe1, 0x93, 0x99, 0xb5,
+	0x1f, 0xfd, 0x72, 0x56, 0x50, 0x7d, 0xa0, 0x4b, 0x2c, 0x4c, 0xc9, 0x52, 0xaa, 0x37, 0xa0, 0x9f,
+	0xcf, 0x20, 0x4f, 0xf6, 0x8e, 0x85, 0x75, 0x0d, 0xb1, 0x9d, 0x3d, 0x87, 0xaf, 0x72, 0x6d, 0x8b,
+	0x5d, 0xc2, 0xad, 0xa2, 0xe2, 0x39, 0x80, 0x89, 0xf9, 0x2b, 0x9c, 0x95, 0xf1, 0x5b, 0x68, 0xda,
+	0xf9, 0x97, 0x3a, 0x6a, 0x0e, 0x26, 0x0e, 0x7c, 0xd4, 0x80, 0x7c, 0xdb, 0x23, 0x38, 0xa4, 0x68,
+	0x4e, 0x2a, 0x2f, 0xb0, 0xee, 0x05, 0xe4, 0x45, 0x09, 0x9a, 0x7d, 0xe1, 0x53, 0x0d, 0x75, 0x81,
+	0xb2, 0xdf, 0x81, 0xfa, 0x0c, 0xd3, 0x5b, 0x94, 0x9d, 0x17, 0xbc, 0x78, 0xf1, 0xef, 0x0c, 0xe8,
+	0xe1, 0x3c, 0x2d, 0xb3, 0x2b, 0xc5, 0xe4, 0x07, 0x8a, 0x26, 0xe4, 0x9b, 0x98, 0x85, 0xd4, 0xad,
+	0xae, 0xef, 0x05, 0x94, 0x84, 0x96, 0x1b, 0x59, 0x35, 0x7f, 0xba, 0xb1, 0xff, 0xef, 0x77, 0x6b,
+	0xca, 0x8f, 0xef, 0xd6, 0x94, 0xff, 0xbe, 0x5b, 0x53, 0xfe, 0xf4, 0x7e, 0xed, 0xce, 0x8f, 0xef,
+	0xd7, 0xee, 0xfc, 0xe7, 0xfd, 0xda, 0x9d, 0xef, 0x77, 0x52, 0x1f, 0x4e, 0xdf, 0x0e, 0xac, 0x3e,
+	0x21, 0xd8, 0xdb, 0xe6, 0xba, 0xc4, 0x27, 0xd4, 0xcd, 0x13, 0x26, 0x27, 0xdf, 0x63, 0xad, 0xc0,
+	0x3d, 0x7b, 0xd2, 0xcd, 0xf3, 0x99, 0xaf, 0xfe, 0x3f, 0x00, 0xeb, 0x87, 0x33, 0x12, 0xa8, 0x15,
+	0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// AccountsAPIClient is the client API for AccountsAPI service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type AccountsAPIClient interface {
+	Create(ctx context.Context, in *AccountParams, opts ...grpc.CallOption) (*AccountID, error)
+	UpdateEmail(ctx context.Context, in *AccountParams, opts ...grpc.CallOption) (*AccountID, error)
+	UpdatePassword(ctx context.Context, in *AccountParams, opts ...grpc.CallOption) (*AccountID, error)
+	AddRoles(ctx context.Context, in *AccountPrivileges, opts ...grpc.CallOption) (*AccountID, error)
+	RemoveRoles(ctx context.Context, in *AccountPrivileges, opts ...grpc.CallOption) (*AccountID, error)
+	SetRoles(ctx context.Context, in *AccountPrivileges, opts ...grpc.CallOption) (*AccountID, error)
+	UpdateStatus(ctx context.Context, in *AccountPrivileges, opts ...grpc.CallOption) (*AccountID, error)
+	GetByUID(ctx context.Context, in *AccountID, opts ...grpc.CallOption) (*Account, error)
+	Authn(ctx context.Context, in *Credentials, opts ...grpc.CallOption) (*JwtAuthTokens, error)
+	Refresh(ctx context.Context, in *JwtAuthTokens, opts ...grpc.CallOption) (*JwtAuthTokens, error)
+	Logout(ctx context.Context, in *JwtAuthTokens, opts ...grpc.CallOption) (*AccountID, error)
+	RevokeAllSessions(ctx context.Context, in *AccountID, opts ...grpc.CallOption) (*AccountID, error)
+	Introspect(ctx context.Context, in *IntrospectionReq, opts ...grpc.CallOption) (*IntrospectionResp, error)
+	ExchangeToken(ctx context.Context, in *TokenExchangeReq, opts ...grpc.CallOption) (*TokenExchangeResp, error)
+	RequestPasswordReset(ctx context.Context, in *PasswordResetReq, opts ...grpc.CallOption) (*AccountID, error)
+	CompletePasswordReset(ctx context.Context, in *CompletePasswordResetReq, opts ...grpc.CallOption) (*AccountID, error)
+	VerifyEmail(ctx context.Context, in *VerifyEmailReq, opts ...grpc.CallOption) (*AccountID, error)
+	ResendVerification(ctx context.Context, in *AccountID, opts ...grpc.CallOption) (*AccountID, error)
+	Reactivate(ctx context.Context, in *AccountID, opts ...grpc.CallOption) (*AccountID, error)
+}
+
+type accountsAPIClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewAccountsAPIClient(cc *grpc.ClientConn) AccountsAPIClient {
+	return &accountsAPIClient{cc}
+}
+
+func (c *accountsAPIClient) Create(ctx context.Context, in *AccountParams, opts ...grpc.CallOption) (*AccountID, error) {
+	out := new(AccountID)
+	err := c.cc.Invoke(ctx, "/authn.accounts.v1.AccountsAPI/Create", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	if len(m.ParentAccount) > 0 {
-		dAtA[i] = 0x4a
-		i++
-		i = encodeVarintAccountsApi(dAtA, i, uint64(len(m.ParentAccount)))
-		i += copy(dAtA[i:], m.ParentAccount)
+	return out, nil
+}
+
+func (c *accountsAPIClient) UpdateEmail(ctx context.Context, in *AccountParams, opts ...grpc.CallOption) (*AccountID, error) {
+	out := new(AccountID)
+	err := c.cc.Invoke(ctx, "/authn.accounts.v1.AccountsAPI/UpdateEmail", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	return i, nil
+	return out, nil
 }
 
-func (m *Info) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
+func (c *accountsAPIClient) UpdatePassword(ctx context.Context, in *AccountParams, opts ...grpc.CallOption) (*AccountID, error) {
+	out := new(AccountID)
+	err := c.cc.Invoke(ctx, "/authn.accounts.v1.AccountsAPI/UpdatePassword", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	return out, nil
 }
 
-func (m *Info) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.Type) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintAccountsApi(dAtA, i, uint64(len(m.Type)))
-		i += copy(dAtA[i:], m.Type)
+func (c *accountsAPIClient) AddRoles(ctx context.Context, in *AccountPrivileges, opts ...grpc.CallOption) (*AccountID, error) {
+	out := new(AccountID)
+	err := c.cc.Invoke(ctx, "/authn.accounts.v1.AccountsAPI/AddRoles", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	if len(m.Uid) > 0 {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintAccountsApi(dAtA, i, uint64(len(m.Uid)))
-		i += copy(dAtA[i:], m.Uid)
+	return out, nil
+}
+
+func (c *accountsAPIClient) RemoveRoles(ctx context.Context, in *AccountPrivileges, opts ...grpc.CallOption) (*AccountID, error) {
+	out := new(AccountID)
+	err := c.cc.Invoke(ctx, "/authn.accounts.v1.AccountsAPI/RemoveRoles", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	if m.Status != 0 {
-		dAtA[i] = 0x18
-		i++
-		i = encodeVarintAccountsApi(dAtA, i, uint64(m.Status))
+	return out, nil
+}
+
+func (c *accountsAPIClient) SetRoles(ctx context.Context, in *AccountPrivileges, opts ...grpc.CallOption) (*AccountID, error) {
+	out := new(AccountID)
+	err := c.cc.Invoke(ctx, "/authn.accounts.v1.AccountsAPI/SetRoles", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	if len(m.Roles) > 0 {
-		for _, s := range m.Roles {
-			dAtA[i] = 0x22
-			i++
-			l = len(s)
-			for l >= 1<<7 {
-				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
-				l >>= 7
-				i++
-			}
-			dAtA[i] = uint8(l)
-			i++
-			i += copy(dAtA[i:], s)
-		}
+	return out, nil
+}
+
+func (c *accountsAPIClient) UpdateStatus(ctx context.Context, in *AccountPrivileges, opts ...grpc.CallOption) (*AccountID, error) {
+	out := new(AccountID)
+	err := c.cc.Invoke(ctx, "/authn.accounts.v1.AccountsAPI/UpdateStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	return i, nil
+	return out, nil
 }
 
-func (m *MultiAccounts) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
+func (c *accountsAPIClient) GetByUID(ctx context.Context, in *AccountID, opts ...grpc.CallOption) (*Account, error) {
+	out := new(Account)
+	err := c.cc.Invoke(ctx, "/authn.accounts.v1.AccountsAPI/GetByUID", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	return out, nil
 }
 
-func (m *MultiAccounts) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.Accounts) > 0 {
-		for _, msg := range m.Accounts {
-			dAtA[i] = 0xa
-			i++
-			i = encodeVarintAccountsApi(dAtA, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(dAtA[i:])
-			if err != nil {
-				return 0, err
-			}
-			i += n
-		}
+func (c *accountsAPIClient) Authn(ctx context.Context, in *Credentials, opts ...grpc.CallOption) (*JwtAuthTokens, error) {
+	out := new(JwtAuthTokens)
+	err := c.cc.Invoke(ctx, "/authn.accounts.v1.AccountsAPI/Authn", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	return i, nil
+	return out, nil
 }
 
-func (m *AccountID) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
+func (c *accountsAPIClient) Refresh(ctx context.Context, in *JwtAuthTokens, opts ...grpc.CallOption) (*JwtAuthTokens, error) {
+	out := new(JwtAuthTokens)
+	err := c.cc.Invoke(ctx, "/authn.accounts.v1.AccountsAPI/Refresh", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	return out, nil
 }
 
-func (m *AccountID) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.Id) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintAccountsApi(dAtA, i, uint64(len(m.Id)))
-		i += copy(dAtA[i:], m.Id)
+func (c *accountsAPIClient) Logout(ctx context.Context, in *JwtAuthTokens, opts ...grpc.CallOption) (*AccountID, error) {
+	out := new(AccountID)
+	err := c.cc.Invoke(ctx, "/authn.accounts.v1.AccountsAPI/Logout", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	if m.Type != 0 {
-		dAtA[i] = 0x10
-		i++
-		i = encodeVarintAccountsApi(dAtA, i, uint64(m.Type))
+	return out, nil
+}
+
+func (c *accountsAPIClient) RevokeAllSessions(ctx context.Context, in *AccountID, opts ...grpc.CallOption) (*AccountID, error) {
+	out := new(AccountID)
+	err := c.cc.Invoke(ctx, "/authn.accounts.v1.AccountsAPI/RevokeAllSessions", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	return i, nil
+	return out, nil
 }
 
-func (m *AccountIDs) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
+func (c *accountsAPIClient) Introspect(ctx context.Context, in *IntrospectionReq, opts ...grpc.CallOption) (*IntrospectionResp, error) {
+	out := new(IntrospectionResp)
+	err := c.cc.Invoke(ctx, "/authn.accounts.v1.AccountsAPI/Introspect", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	return out, nil
 }
 
-func (m *AccountIDs) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.Ids) > 0 {
-		for _, s := range m.Ids {
-			dAtA[i] = 0xa
-			i++
-			l = len(s)
-			for l >= 1<<7 {
-				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
-				l >>= 7
-				i++
-			}
-			dAtA[i] = uint8(l)
-			i++
-			i += copy(dAtA[i:], s)
-		}
-	}
-	if m.Type != 0 {
-		dAtA[i] = 0x10
-		i++
-		i = encodeVarintAccountsApi(dAtA, i, uint64(m.Type))
+func (c *accountsAPIClient) ExchangeToken(ctx context.Context, in *TokenExchangeReq, opts ...grpc.CallOption) (*TokenExchangeResp, error) {
+	out := new(TokenExchangeResp)
+	err := c.cc.Invoke(ctx, "/authn.accounts.v1.AccountsAPI/ExchangeToken", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	return i, nil
+	return out, nil
 }
 
-func (m *AccountParams) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
+func (c *accountsAPIClient) RequestPasswordReset(ctx context.Context, in *PasswordResetReq, opts ...grpc.CallOption) (*AccountID, error) {
+	out := new(AccountID)
+	err := c.cc.Invoke(ctx, "/authn.accounts.v1.AccountsAPI/RequestPasswordReset", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	return out, nil
 }
 
-func (m *AccountParams) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.Uid) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintAccountsApi(dAtA, i, uint64(len(m.Uid)))
-		i += copy(dAtA[i:], m.Uid)
-	}
-	if len(m.Email) > 0 {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintAccountsApi(dAtA, i, uint64(len(m.Email)))
-		i += copy(dAtA[i:], m.Email)
-	}
-	if len(m.Pwd) > 0 {
-		dAtA[i] = 0x1a
-		i++
-		i = encodeVarintAccountsApi(dAtA, i, uint64(len(m.Pwd)))
-		i += copy(dAtA[i:], m.Pwd)
-	}
-	if len(m.Parent) > 0 {
-		dAtA[i] = 0x22
-		i++
-		i = encodeVarintAccountsApi(dAtA, i, uint64(len(m.Parent)))
-		i += copy(dAtA[i:], m.Parent)
+func (c *accountsAPIClient) CompletePasswordReset(ctx context.Context, in *CompletePasswordResetReq, opts ...grpc.CallOption) (*AccountID, error) {
+	out := new(AccountID)
+	err := c.cc.Invoke(ctx, "/authn.accounts.v1.AccountsAPI/CompletePasswordReset", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	return i, nil
+	return out, nil
 }
 
-func (m *AccountPrivileges) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
+func (c *accountsAPIClient) VerifyEmail(ctx context.Context, in *VerifyEmailReq, opts ...grpc.CallOption) (*AccountID, error) {
+	out := new(AccountID)
+	err := c.cc.Invoke(ctx, "/authn.accounts.v1.AccountsAPI/VerifyEmail", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	return out, nil
 }
 
-func (m *AccountPrivileges) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.Uid) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintAccountsApi(dAtA, i, uint64(len(m.Uid)))
-		i += copy(dAtA[i:], m.Uid)
-	}
-	if len(m.Roles) > 0 {
-		for _, s := range m.Roles {
-			dAtA[i] = 0x12
-			i++
-			l = len(s)
-			for l >= 1<<7 {
-				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
-				l >>= 7
-				i++
-			}
-			dAtA[i] = uint8(l)
-			i++
-			i += copy(dAtA[i:], s)
-		}
-	}
-	if m.Status != 0 {
-		dAtA[i] = 0x18
-		i++
-		i = encodeVarintAccountsApi(dAtA, i, uint64(m.Status))
+func (c *accountsAPIClient) ResendVerification(ctx context.Context, in *AccountID, opts ...grpc.CallOption) (*AccountID, error) {
+	out := new(AccountID)
+	err := c.cc.Invoke(ctx, "/authn.accounts.v1.AccountsAPI/ResendVerification", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	return i, nil
+	return out, nil
 }
 
-func (m *JwtAuthTokens) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
+func (c *accountsAPIClient) Reactivate(ctx context.Context, in *AccountID, opts ...grpc.CallOption) (*AccountID, error) {
+	out := new(AccountID)
+	err := c.cc.Invoke(ctx, "/authn.accounts.v1.AccountsAPI/Reactivate", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	return out, nil
 }
 
-func (m *JwtAuthTokens) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.Access) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintAccountsApi(dAtA, i, uint64(len(m.Access)))
-		i += copy(dAtA[i:], m.Access)
-	}
-	if len(m.Refresh) > 0 {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintAccountsApi(dAtA, i, uint64(len(m.Refresh)))
-		i += copy(dAtA[i:], m.Refresh)
-	}
-	return i, nil
+// AccountsAPIServer is the server API for AccountsAPI service.
+type AccountsAPIServer interface {
+	Create(context.Context, *AccountParams) (*AccountID, error)
+	UpdateEmail(context.Context, *AccountParams) (*AccountID, error)
+	UpdatePassword(context.Context, *AccountParams) (*AccountID, error)
+	AddRoles(context.Context, *AccountPrivileges) (*AccountID, error)
+	RemoveRoles(context.Context, *AccountPrivileges) (*AccountID, error)
+	SetRoles(context.Context, *AccountPrivileges) (*AccountID, error)
+	UpdateStatus(context.Context, *AccountPrivileges) (*AccountID, error)
+	GetByUID(context.Context, *AccountID) (*Account, error)
+	Authn(context.Context, *Credentials) (*JwtAuthTokens, error)
+	Refresh(context.Context, *JwtAuthTokens) (*JwtAuthTokens, error)
+	Logout(context.Context, *JwtAuthTokens) (*AccountID, error)
+	RevokeAllSessions(context.Context, *AccountID) (*AccountID, error)
+	Introspect(context.Context, *IntrospectionReq) (*IntrospectionResp, error)
+	ExchangeToken(context.Context, *TokenExchangeReq) (*TokenExchangeResp, error)
+	RequestPasswordReset(context.Context, *PasswordResetReq) (*AccountID, error)
+	CompletePasswordReset(context.Context, *CompletePasswordResetReq) (*AccountID, error)
+	VerifyEmail(context.Context, *VerifyEmailReq) (*AccountID, error)
+	ResendVerification(context.Context, *AccountID) (*AccountID, error)
+	Reactivate(context.Context, *AccountID) (*AccountID, error)
 }
 
-func (m *Credentials) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+func RegisterAccountsAPIServer(s *grpc.Server, srv AccountsAPIServer) {
+	s.RegisterService(&_AccountsAPI_serviceDesc, srv)
 }
 
-func (m *Credentials) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.Id) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintAccountsApi(dAtA, i, uint64(len(m.Id)))
-		i += copy(dAtA[i:], m.Id)
+func _AccountsAPI_Create_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AccountParams)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	if len(m.Pwd) > 0 {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintAccountsApi(dAtA, i, uint64(len(m.Pwd)))
-		i += copy(dAtA[i:], m.Pwd)
+	if interceptor == nil {
+		return srv.(AccountsAPIServer).Create(ctx, in)
 	}
-	return i, nil
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/authn.accounts.v1.AccountsAPI/Create",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountsAPIServer).Create(ctx, req.(*AccountParams))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *PutAccountParams) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
+func _AccountsAPI_UpdateEmail_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AccountParams)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
-}
-
-func (m *PutAccountParams) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.Uid) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintAccountsApi(dAtA, i, uint64(len(m.Uid)))
-		i += copy(dAtA[i:], m.Uid)
+	if interceptor == nil {
+		return srv.(AccountsAPIServer).UpdateEmail(ctx, in)
 	}
-	if m.Acct != nil {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintAccountsApi(dAtA, i, uint64(m.Acct.Size()))
-		n1, err := m.Acct.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n1
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/authn.accounts.v1.AccountsAPI/UpdateEmail",
 	}
-	return i, nil
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountsAPIServer).UpdateEmail(ctx, req.(*AccountParams))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func encodeVarintAccountsApi(dAtA []byte, offset int, v uint64) int {
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+func _AccountsAPI_UpdatePassword_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AccountParams)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	dAtA[offset] = uint8(v)
-	return offset + 1
-}
-func (m *Account) Size() (n int) {
-	if m == nil {
-		return 0
+	if interceptor == nil {
+		return srv.(AccountsAPIServer).UpdatePassword(ctx, in)
 	}
-	var l int
-	_ = l
-	l = len(m.Uid)
-	if l > 0 {
-		n += 1 + l + sovAccountsApi(uint64(l))
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/authn.accounts.v1.AccountsAPI/UpdatePassword",
 	}
-	l = len(m.Email)
-	if l > 0 {
-		n += 1 + l + sovAccountsApi(uint64(l))
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountsAPIServer).UpdatePassword(ctx, req.(*AccountParams))
 	}
-	l = len(m.Hash)
-	if l > 0 {
-		n += 1 + l + sovAccountsApi(uint64(l))
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccountsAPI_AddRoles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AccountPrivileges)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	if m.CreatedAt != 0 {
-		n += 1 + sovAccountsApi(uint64(m.CreatedAt))
+	if interceptor == nil {
+		return srv.(AccountsAPIServer).AddRoles(ctx, in)
 	}
-	if m.UpdatedAt != 0 {
-		n += 1 + sovAccountsApi(uint64(m.UpdatedAt))
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/authn.accounts.v1.AccountsAPI/AddRoles",
 	}
-	if len(m.Roles) > 0 {
-		for _, s := range m.Roles {
-			l = len(s)
-			n += 1 + l + sovAccountsApi(uint64(l))
-		}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountsAPIServer).AddRoles(ctx, req.(*AccountPrivileges))
 	}
-	if m.Status != 0 {
-		n += 1 + sovAccountsApi(uint64(m.Status))
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccountsAPI_RemoveRoles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AccountPrivileges)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	l = len(m.ParentAccount)
-	if l > 0 {
-		n += 1 + l + sovAccountsApi(uint64(l))
+	if interceptor == nil {
+		return srv.(AccountsAPIServer).RemoveRoles(ctx, in)
 	}
-	return n
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/authn.accounts.v1.AccountsAPI/RemoveRoles",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountsAPIServer).RemoveRoles(ctx, req.(*AccountPrivileges))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *Info) Size() (n int) {
-	if m == nil {
-		return 0
+func _AccountsAPI_SetRoles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AccountPrivileges)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	l = len(m.Type)
-	if l > 0 {
-		n += 1 + l + sovAccountsApi(uint64(l))
+	if interceptor == nil {
+		return srv.(AccountsAPIServer).SetRoles(ctx, in)
 	}
-	l = len(m.Uid)
-	if l > 0 {
-		n += 1 + l + sovAccountsApi(uint64(l))
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/authn.accounts.v1.AccountsAPI/SetRoles",
 	}
-	if m.Status != 0 {
-		n += 1 + sovAccountsApi(uint64(m.Status))
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountsAPIServer).SetRoles(ctx, req.(*AccountPrivileges))
 	}
-	if len(m.Roles) > 0 {
-		for _, s := range m.Roles {
-			l = len(s)
-			n += 1 + l + sovAccountsApi(uint64(l))
-		}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccountsAPI_UpdateStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AccountPrivileges)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	return n
+	if interceptor == nil {
+		return srv.(AccountsAPIServer).UpdateStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/authn.accounts.v1.AccountsAPI/UpdateStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountsAPIServer).UpdateStatus(ctx, req.(*AccountPrivileges))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *MultiAccounts) Size() (n int) {
-	if m == nil {
-		return 0
+func _AccountsAPI_GetByUID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AccountID)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	if len(m.Accounts) > 0 {
-		for _, e := range m.Accounts {
-			l = e.Size()
-			n += 1 + l + sovAccountsApi(uint64(l))
-		}
+	if interceptor == nil {
+		return srv.(AccountsAPIServer).GetByUID(ctx, in)
 	}
-	return n
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/authn.accounts.v1.AccountsAPI/GetByUID",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountsAPIServer).GetByUID(ctx, req.(*AccountID))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *AccountID) Size() (n int) {
-	if m == nil {
-		return 0
+func _AccountsAPI_Authn_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Credentials)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	l = len(m.Id)
-	if l > 0 {
-		n += 1 + l + sovAccountsApi(uint64(l))
+	if interceptor == nil {
+		return srv.(AccountsAPIServer).Authn(ctx, in)
 	}
-	if m.Type != 0 {
-		n += 1 + sovAccountsApi(uint64(m.Type))
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/authn.accounts.v1.AccountsAPI/Authn",
 	}
-	return n
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountsAPIServer).Authn(ctx, req.(*Credentials))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *AccountIDs) Size() (n int) {
-	if m == nil {
-		return 0
+func _AccountsAPI_Refresh_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(JwtAuthTokens)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	if len(m.Ids) > 0 {
-		for _, s := range m.Ids {
-			l = len(s)
-			n += 1 + l + sovAccountsApi(uint64(l))
-		}
+	if interceptor == nil {
+		return srv.(AccountsAPIServer).Refresh(ctx, in)
 	}
-	if m.Type != 0 {
-		n += 1 + sovAccountsApi(uint64(m.Type))
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/authn.accounts.v1.AccountsAPI/Refresh",
 	}
-	return n
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountsAPIServer).Refresh(ctx, req.(*JwtAuthTokens))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *AccountParams) Size() (n int) {
-	if m == nil {
-		return 0
+func _AccountsAPI_Logout_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(JwtAuthTokens)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	l = len(m.Uid)
-	if l > 0 {
-		n += 1 + l + sovAccountsApi(uint64(l))
+	if interceptor == nil {
+		return srv.(AccountsAPIServer).Logout(ctx, in)
 	}
-	l = len(m.Email)
-	if l > 0 {
-		n += 1 + l + sovAccountsApi(uint64(l))
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/authn.accounts.v1.AccountsAPI/Logout",
 	}
-	l = len(m.Pwd)
-	if l > 0 {
-		n += 1 + l + sovAccountsApi(uint64(l))
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountsAPIServer).Logout(ctx, req.(*JwtAuthTokens))
 	}
-	l = len(m.Parent)
-	if l > 0 {
-		n += 1 + l + sovAccountsApi(uint64(l))
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccountsAPI_RevokeAllSessions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AccountID)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	return n
+	if interceptor == nil {
+		return srv.(AccountsAPIServer).RevokeAllSessions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/authn.accounts.v1.AccountsAPI/RevokeAllSessions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountsAPIServer).RevokeAllSessions(ctx, req.(*AccountID))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *AccountPrivileges) Size() (n int) {
-	if m == nil {
-		return 0
+func _AccountsAPI_Introspect_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IntrospectionReq)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	l = len(m.Uid)
-	if l > 0 {
-		n += 1 + l + sovAccountsApi(uint64(l))
+	if interceptor == nil {
+		return srv.(AccountsAPIServer).Introspect(ctx, in)
 	}
-	if len(m.Roles) > 0 {
-		for _, s := range m.Roles {
-			l = len(s)
-			n += 1 + l + sovAccountsApi(uint64(l))
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/authn.accounts.v1.AccountsAPI/Introspect",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountsAPIServer).Introspect(ctx, req.(*IntrospectionReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccountsAPI_ExchangeToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TokenExchangeReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountsAPIServer).ExchangeToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/authn.accounts.v1.AccountsAPI/ExchangeToken",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountsAPIServer).ExchangeToken(ctx, req.(*TokenExchangeReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccountsAPI_RequestPasswordReset_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PasswordResetReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountsAPIServer).RequestPasswordReset(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/authn.accounts.v1.AccountsAPI/RequestPasswordReset",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountsAPIServer).RequestPasswordReset(ctx, req.(*PasswordResetReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccountsAPI_CompletePasswordReset_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CompletePasswordResetReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountsAPIServer).CompletePasswordReset(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/authn.accounts.v1.AccountsAPI/CompletePasswordReset",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountsAPIServer).CompletePasswordReset(ctx, req.(*CompletePasswordResetReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccountsAPI_VerifyEmail_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyEmailReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountsAPIServer).VerifyEmail(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/authn.accounts.v1.AccountsAPI/VerifyEmail",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountsAPIServer).VerifyEmail(ctx, req.(*VerifyEmailReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccountsAPI_ResendVerification_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AccountID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountsAPIServer).ResendVerification(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/authn.accounts.v1.AccountsAPI/ResendVerification",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountsAPIServer).ResendVerification(ctx, req.(*AccountID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccountsAPI_Reactivate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AccountID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountsAPIServer).Reactivate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/authn.accounts.v1.AccountsAPI/Reactivate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountsAPIServer).Reactivate(ctx, req.(*AccountID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _AccountsAPI_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "authn.accounts.v1.AccountsAPI",
+	HandlerType: (*AccountsAPIServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Create",
+			Handler:    _AccountsAPI_Create_Handler,
+		},
+		{
+			MethodName: "UpdateEmail",
+			Handler:    _AccountsAPI_UpdateEmail_Handler,
+		},
+		{
+			MethodName: "UpdatePassword",
+			Handler:    _AccountsAPI_UpdatePassword_Handler,
+		},
+		{
+			MethodName: "AddRoles",
+			Handler:    _AccountsAPI_AddRoles_Handler,
+		},
+		{
+			MethodName: "RemoveRoles",
+			Handler:    _AccountsAPI_RemoveRoles_Handler,
+		},
+		{
+			MethodName: "SetRoles",
+			Handler:    _AccountsAPI_SetRoles_Handler,
+		},
+		{
+			MethodName: "UpdateStatus",
+			Handler:    _AccountsAPI_UpdateStatus_Handler,
+		},
+		{
+			MethodName: "GetByUID",
+			Handler:    _AccountsAPI_GetByUID_Handler,
+		},
+		{
+			MethodName: "Authn",
+			Handler:    _AccountsAPI_Authn_Handler,
+		},
+		{
+			MethodName: "Refresh",
+			Handler:    _AccountsAPI_Refresh_Handler,
+		},
+		{
+			MethodName: "Logout",
+			Handler:    _AccountsAPI_Logout_Handler,
+		},
+		{
+			MethodName: "RevokeAllSessions",
+			Handler:    _AccountsAPI_RevokeAllSessions_Handler,
+		},
+		{
+			MethodName: "Introspect",
+			Handler:    _AccountsAPI_Introspect_Handler,
+		},
+		{
+			MethodName: "ExchangeToken",
+			Handler:    _AccountsAPI_ExchangeToken_Handler,
+		},
+		{
+			MethodName: "RequestPasswordReset",
+			Handler:    _AccountsAPI_RequestPasswordReset_Handler,
+		},
+		{
+			MethodName: "CompletePasswordReset",
+			Handler:    _AccountsAPI_CompletePasswordReset_Handler,
+		},
+		{
+			MethodName: "VerifyEmail",
+			Handler:    _AccountsAPI_VerifyEmail_Handler,
+		},
+		{
+			MethodName: "ResendVerification",
+			Handler:    _AccountsAPI_ResendVerification_Handler,
+		},
+		{
+			MethodName: "Reactivate",
+			Handler:    _AccountsAPI_Reactivate_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "accounts/v1/accounts_api.proto",
+}
+
+// AccountRepoClient is the client API for AccountRepo service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type AccountRepoClient interface {
+	Insert(ctx context.Context, in *Account, opts ...grpc.CallOption) (*AccountID, error)
+	Update(ctx context.Context, in *PutAccountParams, opts ...grpc.CallOption) (*AccountID, error)
+	//Get returns the account of an uid or email: accounts read by email must carry their uid
+	Get(ctx context.Context, in *AccountID, opts ...grpc.CallOption) (*Account, error)
+	GetMulti(ctx context.Context, in *AccountIDs, opts ...grpc.CallOption) (*MultiAccounts, error)
+	Delete(ctx context.Context, in *AccountID, opts ...grpc.CallOption) (*AccountID, error)
+	DeleteMulti(ctx context.Context, in *AccountIDs, opts ...grpc.CallOption) (*AccountIDs, error)
+}
+
+type accountRepoClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewAccountRepoClient(cc *grpc.ClientConn) AccountRepoClient {
+	return &accountRepoClient{cc}
+}
+
+func (c *accountRepoClient) Insert(ctx context.Context, in *Account, opts ...grpc.CallOption) (*AccountID, error) {
+	out := new(AccountID)
+	err := c.cc.Invoke(ctx, "/authn.accounts.v1.AccountRepo/Insert", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountRepoClient) Update(ctx context.Context, in *PutAccountParams, opts ...grpc.CallOption) (*AccountID, error) {
+	out := new(AccountID)
+	err := c.cc.Invoke(ctx, "/authn.accounts.v1.AccountRepo/Update", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountRepoClient) Get(ctx context.Context, in *AccountID, opts ...grpc.CallOption) (*Account, error) {
+	out := new(Account)
+	err := c.cc.Invoke(ctx, "/authn.accounts.v1.AccountRepo/Get", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountRepoClient) GetMulti(ctx context.Context, in *AccountIDs, opts ...grpc.CallOption) (*MultiAccounts, error) {
+	out := new(MultiAccounts)
+	err := c.cc.Invoke(ctx, "/authn.accounts.v1.AccountRepo/GetMulti", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountRepoClient) Delete(ctx context.Context, in *AccountID, opts ...grpc.CallOption) (*AccountID, error) {
+	out := new(AccountID)
+	err := c.cc.Invoke(ctx, "/authn.accounts.v1.AccountRepo/Delete", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountRepoClient) DeleteMulti(ctx context.Context, in *AccountIDs, opts ...grpc.CallOption) (*AccountIDs, error) {
+	out := new(AccountIDs)
+	err := c.cc.Invoke(ctx, "/authn.accounts.v1.AccountRepo/DeleteMulti", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AccountRepoServer is the server API for AccountRepo service.
+type AccountRepoServer interface {
+	Insert(context.Context, *Account) (*AccountID, error)
+	Update(context.Context, *PutAccountParams) (*AccountID, error)
+	//Get returns the account of an uid or email: accounts read by email must carry their uid
+	Get(context.Context, *AccountID) (*Account, error)
+	GetMulti(context.Context, *AccountIDs) (*MultiAccounts, error)
+	Delete(context.Context, *AccountID) (*AccountID, error)
+	DeleteMulti(context.Context, *AccountIDs) (*AccountIDs, error)
+}
+
+func RegisterAccountRepoServer(s *grpc.Server, srv AccountRepoServer) {
+	s.RegisterService(&_AccountRepo_serviceDesc, srv)
+}
+
+func _AccountRepo_Insert_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Account)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountRepoServer).Insert(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/authn.accounts.v1.AccountRepo/Insert",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountRepoServer).Insert(ctx, req.(*Account))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccountRepo_Update_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PutAccountParams)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountRepoServer).Update(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/authn.accounts.v1.AccountRepo/Update",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountRepoServer).Update(ctx, req.(*PutAccountParams))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccountRepo_Get_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AccountID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountRepoServer).Get(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/authn.accounts.v1.AccountRepo/Get",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountRepoServer).Get(ctx, req.(*AccountID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccountRepo_GetMulti_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AccountIDs)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountRepoServer).GetMulti(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/authn.accounts.v1.AccountRepo/GetMulti",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountRepoServer).GetMulti(ctx, req.(*AccountIDs))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccountRepo_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AccountID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountRepoServer).Delete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/authn.accounts.v1.AccountRepo/Delete",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountRepoServer).Delete(ctx, req.(*AccountID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccountRepo_DeleteMulti_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AccountIDs)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountRepoServer).DeleteMulti(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/authn.accounts.v1.AccountRepo/DeleteMulti",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountRepoServer).DeleteMulti(ctx, req.(*AccountIDs))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _AccountRepo_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "authn.accounts.v1.AccountRepo",
+	HandlerType: (*AccountRepoServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Insert",
+			Handler:    _AccountRepo_Insert_Handler,
+		},
+		{
+			MethodName: "Update",
+			Handler:    _AccountRepo_Update_Handler,
+		},
+		{
+			MethodName: "Get",
+			Handler:    _AccountRepo_Get_Handler,
+		},
+		{
+			MethodName: "GetMulti",
+			Handler:    _AccountRepo_GetMulti_Handler,
+		},
+		{
+			MethodName: "Delete",
+			Handler:    _AccountRepo_Delete_Handler,
+		},
+		{
+			MethodName: "DeleteMulti",
+			Handler:    _AccountRepo_DeleteMulti_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "accounts/v1/accounts_api.proto",
+}
+
+func (m *Account) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Account) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Uid) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintAccountsApi(dAtA, i, uint64(len(m.Uid)))
+		i += copy(dAtA[i:], m.Uid)
+	}
+	if len(m.Email) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintAccountsApi(dAtA, i, uint64(len(m.Email)))
+		i += copy(dAtA[i:], m.Email)
+	}
+	if len(m.Hash) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintAccountsApi(dAtA, i, uint64(len(m.Hash)))
+		i += copy(dAtA[i:], m.Hash)
+	}
+	if m.CreatedAt != 0 {
+		dAtA[i] = 0x28
+		i++
+		i = encodeVarintAccountsApi(dAtA, i, uint64(m.CreatedAt))
+	}
+	if m.UpdatedAt != 0 {
+		dAtA[i] = 0x30
+		i++
+		i = encodeVarintAccountsApi(dAtA, i, uint64(m.UpdatedAt))
+	}
+	if len(m.Roles) > 0 {
+		for _, s := range m.Roles {
+			dAtA[i] = 0x3a
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if m.Status != 0 {
+		dAtA[i] = 0x40
+		i++
+		i = encodeVarintAccountsApi(dAtA, i, uint64(m.Status))
+	}
+	if len(m.ParentAccount) > 0 {
+		dAtA[i] = 0x4a
+		i++
+		i = encodeVarintAccountsApi(dAtA, i, uint64(len(m.ParentAccount)))
+		i += copy(dAtA[i:], m.ParentAccount)
+	}
+	if len(m.HashHistory) > 0 {
+		for _, s := range m.HashHistory {
+			dAtA[i] = 0x52
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if m.PwdChangedAt != 0 {
+		dAtA[i] = 0x58
+		i++
+		i = encodeVarintAccountsApi(dAtA, i, uint64(m.PwdChangedAt))
+	}
+	if m.EmailVerified {
+		dAtA[i] = 0x60
+		i++
+		if m.EmailVerified {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	return i, nil
+}
+
+func (m *Info) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Info) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Type) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintAccountsApi(dAtA, i, uint64(len(m.Type)))
+		i += copy(dAtA[i:], m.Type)
+	}
+	if len(m.Uid) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintAccountsApi(dAtA, i, uint64(len(m.Uid)))
+		i += copy(dAtA[i:], m.Uid)
+	}
+	if m.Status != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintAccountsApi(dAtA, i, uint64(m.Status))
+	}
+	if len(m.Roles) > 0 {
+		for _, s := range m.Roles {
+			dAtA[i] = 0x22
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if m.MustChangePwd {
+		dAtA[i] = 0x28
+		i++
+		if m.MustChangePwd {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	return i, nil
+}
+
+func (m *MultiAccounts) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *MultiAccounts) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Accounts) > 0 {
+		for _, msg := range m.Accounts {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintAccountsApi(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	return i, nil
+}
+
+func (m *AccountID) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AccountID) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Id) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintAccountsApi(dAtA, i, uint64(len(m.Id)))
+		i += copy(dAtA[i:], m.Id)
+	}
+	if m.Type != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintAccountsApi(dAtA, i, uint64(m.Type))
+	}
+	return i, nil
+}
+
+func (m *AccountIDs) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AccountIDs) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Ids) > 0 {
+		for _, s := range m.Ids {
+			dAtA[i] = 0xa
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if m.Type != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintAccountsApi(dAtA, i, uint64(m.Type))
+	}
+	return i, nil
+}
+
+func (m *AccountParams) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AccountParams) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Uid) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintAccountsApi(dAtA, i, uint64(len(m.Uid)))
+		i += copy(dAtA[i:], m.Uid)
+	}
+	if len(m.Email) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintAccountsApi(dAtA, i, uint64(len(m.Email)))
+		i += copy(dAtA[i:], m.Email)
+	}
+	if len(m.Pwd) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintAccountsApi(dAtA, i, uint64(len(m.Pwd)))
+		i += copy(dAtA[i:], m.Pwd)
+	}
+	if len(m.Parent) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintAccountsApi(dAtA, i, uint64(len(m.Parent)))
+		i += copy(dAtA[i:], m.Parent)
+	}
+	return i, nil
+}
+
+func (m *AccountPrivileges) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AccountPrivileges) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Uid) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintAccountsApi(dAtA, i, uint64(len(m.Uid)))
+		i += copy(dAtA[i:], m.Uid)
+	}
+	if len(m.Roles) > 0 {
+		for _, s := range m.Roles {
+			dAtA[i] = 0x12
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if m.Status != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintAccountsApi(dAtA, i, uint64(m.Status))
+	}
+	return i, nil
+}
+
+func (m *JwtAuthTokens) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *JwtAuthTokens) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Access) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintAccountsApi(dAtA, i, uint64(len(m.Access)))
+		i += copy(dAtA[i:], m.Access)
+	}
+	if len(m.Refresh) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintAccountsApi(dAtA, i, uint64(len(m.Refresh)))
+		i += copy(dAtA[i:], m.Refresh)
+	}
+	if len(m.DpopProof) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintAccountsApi(dAtA, i, uint64(len(m.DpopProof)))
+		i += copy(dAtA[i:], m.DpopProof)
+	}
+	return i, nil
+}
+
+func (m *Credentials) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Credentials) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Id) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintAccountsApi(dAtA, i, uint64(len(m.Id)))
+		i += copy(dAtA[i:], m.Id)
+	}
+	if len(m.Pwd) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintAccountsApi(dAtA, i, uint64(len(m.Pwd)))
+		i += copy(dAtA[i:], m.Pwd)
+	}
+	if len(m.Audiences) > 0 {
+		for _, s := range m.Audiences {
+			dAtA[i] = 0x1a
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if len(m.DpopProof) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintAccountsApi(dAtA, i, uint64(len(m.DpopProof)))
+		i += copy(dAtA[i:], m.DpopProof)
+	}
+	if len(m.ClientId) > 0 {
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintAccountsApi(dAtA, i, uint64(len(m.ClientId)))
+		i += copy(dAtA[i:], m.ClientId)
+	}
+	if len(m.ClientSecret) > 0 {
+		dAtA[i] = 0x32
+		i++
+		i = encodeVarintAccountsApi(dAtA, i, uint64(len(m.ClientSecret)))
+		i += copy(dAtA[i:], m.ClientSecret)
+	}
+	return i, nil
+}
+
+func (m *IntrospectionReq) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *IntrospectionReq) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Token) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintAccountsApi(dAtA, i, uint64(len(m.Token)))
+		i += copy(dAtA[i:], m.Token)
+	}
+	if len(m.TokenTypeHint) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintAccountsApi(dAtA, i, uint64(len(m.TokenTypeHint)))
+		i += copy(dAtA[i:], m.TokenTypeHint)
+	}
+	if len(m.ClientId) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintAccountsApi(dAtA, i, uint64(len(m.ClientId)))
+		i += copy(dAtA[i:], m.ClientId)
+	}
+	if len(m.ClientSecret) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintAccountsApi(dAtA, i, uint64(len(m.ClientSecret)))
+		i += copy(dAtA[i:], m.ClientSecret)
+	}
+	return i, nil
+}
+
+func (m *IntrospectionResp) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *IntrospectionResp) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Active {
+		dAtA[i] = 0x8
+		i++
+		if m.Active {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if len(m.Sub) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintAccountsApi(dAtA, i, uint64(len(m.Sub)))
+		i += copy(dAtA[i:], m.Sub)
+	}
+	if m.Exp != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintAccountsApi(dAtA, i, uint64(m.Exp))
+	}
+	if m.Iat != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintAccountsApi(dAtA, i, uint64(m.Iat))
+	}
+	if m.Nbf != 0 {
+		dAtA[i] = 0x28
+		i++
+		i = encodeVarintAccountsApi(dAtA, i, uint64(m.Nbf))
+	}
+	if len(m.Scope) > 0 {
+		dAtA[i] = 0x32
+		i++
+		i = encodeVarintAccountsApi(dAtA, i, uint64(len(m.Scope)))
+		i += copy(dAtA[i:], m.Scope)
+	}
+	if len(m.ClientId) > 0 {
+		dAtA[i] = 0x3a
+		i++
+		i = encodeVarintAccountsApi(dAtA, i, uint64(len(m.ClientId)))
+		i += copy(dAtA[i:], m.ClientId)
+	}
+	if len(m.Iss) > 0 {
+		dAtA[i] = 0x42
+		i++
+		i = encodeVarintAccountsApi(dAtA, i, uint64(len(m.Iss)))
+		i += copy(dAtA[i:], m.Iss)
+	}
+	if len(m.Aud) > 0 {
+		for _, s := range m.Aud {
+			dAtA[i] = 0x4a
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if len(m.Jti) > 0 {
+		dAtA[i] = 0x52
+		i++
+		i = encodeVarintAccountsApi(dAtA, i, uint64(len(m.Jti)))
+		i += copy(dAtA[i:], m.Jti)
+	}
+	if len(m.TokenType) > 0 {
+		dAtA[i] = 0x5a
+		i++
+		i = encodeVarintAccountsApi(dAtA, i, uint64(len(m.TokenType)))
+		i += copy(dAtA[i:], m.TokenType)
+	}
+	if len(m.Type) > 0 {
+		dAtA[i] = 0x62
+		i++
+		i = encodeVarintAccountsApi(dAtA, i, uint64(len(m.Type)))
+		i += copy(dAtA[i:], m.Type)
+	}
+	if m.Status != 0 {
+		dAtA[i] = 0x68
+		i++
+		i = encodeVarintAccountsApi(dAtA, i, uint64(m.Status))
+	}
+	if len(m.Roles) > 0 {
+		for _, s := range m.Roles {
+			dAtA[i] = 0x72
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if m.MustChangePwd {
+		dAtA[i] = 0x78
+		i++
+		if m.MustChangePwd {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	return i, nil
+}
+
+func (m *TokenExchangeReq) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TokenExchangeReq) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.SubjectToken) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintAccountsApi(dAtA, i, uint64(len(m.SubjectToken)))
+		i += copy(dAtA[i:], m.SubjectToken)
+	}
+	if len(m.ActorToken) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintAccountsApi(dAtA, i, uint64(len(m.ActorToken)))
+		i += copy(dAtA[i:], m.ActorToken)
+	}
+	if len(m.Audiences) > 0 {
+		for _, s := range m.Audiences {
+			dAtA[i] = 0x1a
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if len(m.Roles) > 0 {
+		for _, s := range m.Roles {
+			dAtA[i] = 0x22
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if len(m.Scopes) > 0 {
+		for _, s := range m.Scopes {
+			dAtA[i] = 0x2a
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	return i, nil
+}
+
+func (m *TokenExchangeResp) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TokenExchangeResp) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.AccessToken) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintAccountsApi(dAtA, i, uint64(len(m.AccessToken)))
+		i += copy(dAtA[i:], m.AccessToken)
+	}
+	if len(m.IssuedTokenType) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintAccountsApi(dAtA, i, uint64(len(m.IssuedTokenType)))
+		i += copy(dAtA[i:], m.IssuedTokenType)
+	}
+	if len(m.TokenType) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintAccountsApi(dAtA, i, uint64(len(m.TokenType)))
+		i += copy(dAtA[i:], m.TokenType)
+	}
+	if m.ExpiresIn != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintAccountsApi(dAtA, i, uint64(m.ExpiresIn))
+	}
+	if len(m.Scope) > 0 {
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintAccountsApi(dAtA, i, uint64(len(m.Scope)))
+		i += copy(dAtA[i:], m.Scope)
+	}
+	return i, nil
+}
+
+func (m *PasswordResetReq) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PasswordResetReq) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Email) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintAccountsApi(dAtA, i, uint64(len(m.Email)))
+		i += copy(dAtA[i:], m.Email)
+	}
+	return i, nil
+}
+
+func (m *CompletePasswordResetReq) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CompletePasswordResetReq) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Token) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintAccountsApi(dAtA, i, uint64(len(m.Token)))
+		i += copy(dAtA[i:], m.Token)
+	}
+	if len(m.Pwd) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintAccountsApi(dAtA, i, uint64(len(m.Pwd)))
+		i += copy(dAtA[i:], m.Pwd)
+	}
+	return i, nil
+}
+
+func (m *VerifyEmailReq) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *VerifyEmailReq) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Code) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintAccountsApi(dAtA, i, uint64(len(m.Code)))
+		i += copy(dAtA[i:], m.Code)
+	}
+	return i, nil
+}
+
+func (m *PutAccountParams) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PutAccountParams) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Uid) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintAccountsApi(dAtA, i, uint64(len(m.Uid)))
+		i += copy(dAtA[i:], m.Uid)
+	}
+	if m.Acct != nil {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintAccountsApi(dAtA, i, uint64(m.Acct.Size()))
+		n1, err := m.Acct.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n1
+	}
+	return i, nil
+}
+
+func encodeVarintAccountsApi(dAtA []byte, offset int, v uint64) int {
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return offset + 1
+}
+func (m *Account) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Uid)
+	if l > 0 {
+		n += 1 + l + sovAccountsApi(uint64(l))
+	}
+	l = len(m.Email)
+	if l > 0 {
+		n += 1 + l + sovAccountsApi(uint64(l))
+	}
+	l = len(m.Hash)
+	if l > 0 {
+		n += 1 + l + sovAccountsApi(uint64(l))
+	}
+	if m.CreatedAt != 0 {
+		n += 1 + sovAccountsApi(uint64(m.CreatedAt))
+	}
+	if m.UpdatedAt != 0 {
+		n += 1 + sovAccountsApi(uint64(m.UpdatedAt))
+	}
+	if len(m.Roles) > 0 {
+		for _, s := range m.Roles {
+			l = len(s)
+			n += 1 + l + sovAccountsApi(uint64(l))
+		}
+	}
+	if m.Status != 0 {
+		n += 1 + sovAccountsApi(uint64(m.Status))
+	}
+	l = len(m.ParentAccount)
+	if l > 0 {
+		n += 1 + l + sovAccountsApi(uint64(l))
+	}
+	if len(m.HashHistory) > 0 {
+		for _, s := range m.HashHistory {
+			l = len(s)
+			n += 1 + l + sovAccountsApi(uint64(l))
+		}
+	}
+	if m.PwdChangedAt != 0 {
+		n += 1 + sovAccountsApi(uint64(m.PwdChangedAt))
+	}
+	if m.EmailVerified {
+		n += 2
+	}
+	return n
+}
+
+func (m *Info) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Type)
+	if l > 0 {
+		n += 1 + l + sovAccountsApi(uint64(l))
+	}
+	l = len(m.Uid)
+	if l > 0 {
+		n += 1 + l + sovAccountsApi(uint64(l))
+	}
+	if m.Status != 0 {
+		n += 1 + sovAccountsApi(uint64(m.Status))
+	}
+	if len(m.Roles) > 0 {
+		for _, s := range m.Roles {
+			l = len(s)
+			n += 1 + l + sovAccountsApi(uint64(l))
+		}
+	}
+	if m.MustChangePwd {
+		n += 2
+	}
+	return n
+}
+
+func (m *MultiAccounts) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Accounts) > 0 {
+		for _, e := range m.Accounts {
+			l = e.Size()
+			n += 1 + l + sovAccountsApi(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *AccountID) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Id)
+	if l > 0 {
+		n += 1 + l + sovAccountsApi(uint64(l))
+	}
+	if m.Type != 0 {
+		n += 1 + sovAccountsApi(uint64(m.Type))
+	}
+	return n
+}
+
+func (m *AccountIDs) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Ids) > 0 {
+		for _, s := range m.Ids {
+			l = len(s)
+			n += 1 + l + sovAccountsApi(uint64(l))
+		}
+	}
+	if m.Type != 0 {
+		n += 1 + sovAccountsApi(uint64(m.Type))
+	}
+	return n
+}
+
+func (m *AccountParams) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Uid)
+	if l > 0 {
+		n += 1 + l + sovAccountsApi(uint64(l))
+	}
+	l = len(m.Email)
+	if l > 0 {
+		n += 1 + l + sovAccountsApi(uint64(l))
+	}
+	l = len(m.Pwd)
+	if l > 0 {
+		n += 1 + l + sovAccountsApi(uint64(l))
+	}
+	l = len(m.Parent)
+	if l > 0 {
+		n += 1 + l + sovAccountsApi(uint64(l))
+	}
+	return n
+}
+
+func (m *AccountPrivileges) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Uid)
+	if l > 0 {
+		n += 1 + l + sovAccountsApi(uint64(l))
+	}
+	if len(m.Roles) > 0 {
+		for _, s := range m.Roles {
+			l = len(s)
+			n += 1 + l + sovAccountsApi(uint64(l))
+		}
+	}
+	if m.Status != 0 {
+		n += 1 + sovAccountsApi(uint64(m.Status))
+	}
+	return n
+}
+
+func (m *JwtAuthTokens) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Access)
+	if l > 0 {
+		n += 1 + l + sovAccountsApi(uint64(l))
+	}
+	l = len(m.Refresh)
+	if l > 0 {
+		n += 1 + l + sovAccountsApi(uint64(l))
+	}
+	l = len(m.DpopProof)
+	if l > 0 {
+		n += 1 + l + sovAccountsApi(uint64(l))
+	}
+	return n
+}
+
+func (m *Credentials) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Id)
+	if l > 0 {
+		n += 1 + l + sovAccountsApi(uint64(l))
+	}
+	l = len(m.Pwd)
+	if l > 0 {
+		n += 1 + l + sovAccountsApi(uint64(l))
+	}
+	if len(m.Audiences) > 0 {
+		for _, s := range m.Audiences {
+			l = len(s)
+			n += 1 + l + sovAccountsApi(uint64(l))
+		}
+	}
+	l = len(m.DpopProof)
+	if l > 0 {
+		n += 1 + l + sovAccountsApi(uint64(l))
+	}
+	l = len(m.ClientId)
+	if l > 0 {
+		n += 1 + l + sovAccountsApi(uint64(l))
+	}
+	l = len(m.ClientSecret)
+	if l > 0 {
+		n += 1 + l + sovAccountsApi(uint64(l))
+	}
+	return n
+}
+
+func (m *IntrospectionReq) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Token)
+	if l > 0 {
+		n += 1 + l + sovAccountsApi(uint64(l))
+	}
+	l = len(m.TokenTypeHint)
+	if l > 0 {
+		n += 1 + l + sovAccountsApi(uint64(l))
+	}
+	l = len(m.ClientId)
+	if l > 0 {
+		n += 1 + l + sovAccountsApi(uint64(l))
+	}
+	l = len(m.ClientSecret)
+	if l > 0 {
+		n += 1 + l + sovAccountsApi(uint64(l))
+	}
+	return n
+}
+
+func (m *IntrospectionResp) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Active {
+		n += 2
+	}
+	l = len(m.Sub)
+	if l > 0 {
+		n += 1 + l + sovAccountsApi(uint64(l))
+	}
+	if m.Exp != 0 {
+		n += 1 + sovAccountsApi(uint64(m.Exp))
+	}
+	if m.Iat != 0 {
+		n += 1 + sovAccountsApi(uint64(m.Iat))
+	}
+	if m.Nbf != 0 {
+		n += 1 + sovAccountsApi(uint64(m.Nbf))
+	}
+	l = len(m.Scope)
+	if l > 0 {
+		n += 1 + l + sovAccountsApi(uint64(l))
+	}
+	l = len(m.ClientId)
+	if l > 0 {
+		n += 1 + l + sovAccountsApi(uint64(l))
+	}
+	l = len(m.Iss)
+	if l > 0 {
+		n += 1 + l + sovAccountsApi(uint64(l))
+	}
+	if len(m.Aud) > 0 {
+		for _, s := range m.Aud {
+			l = len(s)
+			n += 1 + l + sovAccountsApi(uint64(l))
+		}
+	}
+	l = len(m.Jti)
+	if l > 0 {
+		n += 1 + l + sovAccountsApi(uint64(l))
+	}
+	l = len(m.TokenType)
+	if l > 0 {
+		n += 1 + l + sovAccountsApi(uint64(l))
+	}
+	l = len(m.Type)
+	if l > 0 {
+		n += 1 + l + sovAccountsApi(uint64(l))
+	}
+	if m.Status != 0 {
+		n += 1 + sovAccountsApi(uint64(m.Status))
+	}
+	if len(m.Roles) > 0 {
+		for _, s := range m.Roles {
+			l = len(s)
+			n += 1 + l + sovAccountsApi(uint64(l))
+		}
+	}
+	if m.MustChangePwd {
+		n += 2
+	}
+	return n
+}
+
+func (m *TokenExchangeReq) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.SubjectToken)
+	if l > 0 {
+		n += 1 + l + sovAccountsApi(uint64(l))
+	}
+	l = len(m.ActorToken)
+	if l > 0 {
+		n += 1 + l + sovAccountsApi(uint64(l))
+	}
+	if len(m.Audiences) > 0 {
+		for _, s := range m.Audiences {
+			l = len(s)
+			n += 1 + l + sovAccountsApi(uint64(l))
+		}
+	}
+	if len(m.Roles) > 0 {
+		for _, s := range m.Roles {
+			l = len(s)
+			n += 1 + l + sovAccountsApi(uint64(l))
+		}
+	}
+	if len(m.Scopes) > 0 {
+		for _, s := range m.Scopes {
+			l = len(s)
+			n += 1 + l + sovAccountsApi(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *TokenExchangeResp) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.AccessToken)
+	if l > 0 {
+		n += 1 + l + sovAccountsApi(uint64(l))
+	}
+	l = len(m.IssuedTokenType)
+	if l > 0 {
+		n += 1 + l + sovAccountsApi(uint64(l))
+	}
+	l = len(m.TokenType)
+	if l > 0 {
+		n += 1 + l + sovAccountsApi(uint64(l))
+	}
+	if m.ExpiresIn != 0 {
+		n += 1 + sovAccountsApi(uint64(m.ExpiresIn))
+	}
+	l = len(m.Scope)
+	if l > 0 {
+		n += 1 + l + sovAccountsApi(uint64(l))
+	}
+	return n
+}
+
+func (m *PasswordResetReq) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Email)
+	if l > 0 {
+		n += 1 + l + sovAccountsApi(uint64(l))
+	}
+	return n
+}
+
+func (m *CompletePasswordResetReq) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Token)
+	if l > 0 {
+		n += 1 + l + sovAccountsApi(uint64(l))
+	}
+	l = len(m.Pwd)
+	if l > 0 {
+		n += 1 + l + sovAccountsApi(uint64(l))
+	}
+	return n
+}
+
+func (m *VerifyEmailReq) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Code)
+	if l > 0 {
+		n += 1 + l + sovAccountsApi(uint64(l))
+	}
+	return n
+}
+
+func (m *PutAccountParams) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Uid)
+	if l > 0 {
+		n += 1 + l + sovAccountsApi(uint64(l))
+	}
+	if m.Acct != nil {
+		l = m.Acct.Size()
+		n += 1 + l + sovAccountsApi(uint64(l))
+	}
+	return n
+}
+
+func sovAccountsApi(x uint64) (n int) {
+	for {
+		n++
+		x >>= 7
+		if x == 0 {
+			break
+		}
+	}
+	return n
+}
+func sozAccountsApi(x uint64) (n int) {
+	return sovAccountsApi(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *Account) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAccountsApi
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Account: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Account: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Uid", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAccountsApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAccountsApi
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAccountsApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Uid = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Email", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAccountsApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAccountsApi
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAccountsApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Email = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Hash", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAccountsApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAccountsApi
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAccountsApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Hash = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CreatedAt", wireType)
+			}
+			m.CreatedAt = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAccountsApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CreatedAt |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UpdatedAt", wireType)
+			}
+			m.UpdatedAt = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAccountsApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.UpdatedAt |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Roles", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAccountsApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAccountsApi
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAccountsApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Roles = append(m.Roles, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
+			}
+			m.Status = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAccountsApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Status |= AccountStatus(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ParentAccount", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAccountsApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAccountsApi
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAccountsApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ParentAccount = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field HashHistory", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAccountsApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAccountsApi
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAccountsApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.HashHistory = append(m.HashHistory, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 11:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PwdChangedAt", wireType)
+			}
+			m.PwdChangedAt = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAccountsApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.PwdChangedAt |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 12:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EmailVerified", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAccountsApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.EmailVerified = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAccountsApi(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthAccountsApi
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthAccountsApi
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Info) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAccountsApi
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Info: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Info: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAccountsApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAccountsApi
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAccountsApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Type = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Uid", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAccountsApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAccountsApi
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAccountsApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Uid = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
+			}
+			m.Status = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAccountsApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Status |= AccountStatus(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Roles", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAccountsApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAccountsApi
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAccountsApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Roles = append(m.Roles, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MustChangePwd", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAccountsApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.MustChangePwd = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAccountsApi(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthAccountsApi
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthAccountsApi
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *MultiAccounts) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAccountsApi
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: MultiAccounts: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: MultiAccounts: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Accounts", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAccountsApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAccountsApi
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAccountsApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Accounts = append(m.Accounts, &Account{})
+			if err := m.Accounts[len(m.Accounts)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAccountsApi(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthAccountsApi
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthAccountsApi
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AccountID) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAccountsApi
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AccountID: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AccountID: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAccountsApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAccountsApi
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAccountsApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Id = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			m.Type = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAccountsApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Type |= IDType(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAccountsApi(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthAccountsApi
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthAccountsApi
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AccountIDs) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAccountsApi
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AccountIDs: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AccountIDs: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Ids", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAccountsApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAccountsApi
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAccountsApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Ids = append(m.Ids, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			m.Type = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAccountsApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Type |= IDType(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAccountsApi(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthAccountsApi
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthAccountsApi
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AccountParams) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAccountsApi
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AccountParams: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AccountParams: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Uid", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAccountsApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAccountsApi
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAccountsApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Uid = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Email", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAccountsApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAccountsApi
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAccountsApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Email = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Pwd", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAccountsApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAccountsApi
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAccountsApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Pwd = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Parent", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAccountsApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAccountsApi
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAccountsApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Parent = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAccountsApi(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthAccountsApi
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthAccountsApi
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AccountPrivileges) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAccountsApi
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AccountPrivileges: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AccountPrivileges: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Uid", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAccountsApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAccountsApi
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAccountsApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Uid = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Roles", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAccountsApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAccountsApi
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAccountsApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Roles = append(m.Roles, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
+			}
+			m.Status = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAccountsApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Status |= AccountStatus(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAccountsApi(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthAccountsApi
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthAccountsApi
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *JwtAuthTokens) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAccountsApi
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: JwtAuthTokens: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: JwtAuthTokens: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Access", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAccountsApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAccountsApi
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAccountsApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Access = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Refresh", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAccountsApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAccountsApi
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAccountsApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Refresh = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DpopProof", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAccountsApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAccountsApi
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAccountsApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DpopProof = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAccountsApi(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthAccountsApi
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthAccountsApi
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Credentials) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAccountsApi
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Credentials: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Credentials: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAccountsApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAccountsApi
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAccountsApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Id = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Pwd", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAccountsApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAccountsApi
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAccountsApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Pwd = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Audiences", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAccountsApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAccountsApi
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAccountsApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Audiences = append(m.Audiences, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DpopProof", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAccountsApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAccountsApi
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAccountsApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DpopProof = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ClientId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAccountsApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAccountsApi
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAccountsApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ClientId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ClientSecret", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAccountsApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAccountsApi
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAccountsApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ClientSecret = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAccountsApi(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthAccountsApi
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthAccountsApi
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
 		}
 	}
-	if m.Status != 0 {
-		n += 1 + sovAccountsApi(uint64(m.Status))
-	}
-	return n
-}
-
-func (m *JwtAuthTokens) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.Access)
-	if l > 0 {
-		n += 1 + l + sovAccountsApi(uint64(l))
-	}
-	l = len(m.Refresh)
-	if l > 0 {
-		n += 1 + l + sovAccountsApi(uint64(l))
-	}
-	return n
-}
 
-func (m *Credentials) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.Id)
-	if l > 0 {
-		n += 1 + l + sovAccountsApi(uint64(l))
-	}
-	l = len(m.Pwd)
-	if l > 0 {
-		n += 1 + l + sovAccountsApi(uint64(l))
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
 	}
-	return n
+	return nil
 }
-
-func (m *PutAccountParams) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.Uid)
-	if l > 0 {
-		n += 1 + l + sovAccountsApi(uint64(l))
-	}
-	if m.Acct != nil {
-		l = m.Acct.Size()
-		n += 1 + l + sovAccountsApi(uint64(l))
+func (m *IntrospectionReq) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAccountsApi
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: IntrospectionReq: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: IntrospectionReq: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Token", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAccountsApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAccountsApi
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAccountsApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Token = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TokenTypeHint", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAccountsApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAccountsApi
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAccountsApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TokenTypeHint = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ClientId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAccountsApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAccountsApi
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAccountsApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ClientId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ClientSecret", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAccountsApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAccountsApi
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAccountsApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ClientSecret = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAccountsApi(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthAccountsApi
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthAccountsApi
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
 	}
-	return n
-}
 
-func sovAccountsApi(x uint64) (n int) {
-	for {
-		n++
-		x >>= 7
-		if x == 0 {
-			break
-		}
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
 	}
-	return n
-}
-func sozAccountsApi(x uint64) (n int) {
-	return sovAccountsApi(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+	return nil
 }
-func (m *Account) Unmarshal(dAtA []byte) error {
+func (m *IntrospectionResp) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1994,17 +5394,17 @@ func (m *Account) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Account: wiretype end group for non-group")
+			return fmt.Errorf("proto: IntrospectionResp: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Account: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: IntrospectionResp: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Uid", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Active", wireType)
 			}
-			var stringLen uint64
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAccountsApi
@@ -2014,27 +5414,15 @@ func (m *Account) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthAccountsApi
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthAccountsApi
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Uid = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 3:
+			m.Active = bool(v != 0)
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Email", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Sub", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2062,13 +5450,13 @@ func (m *Account) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Email = string(dAtA[iNdEx:postIndex])
+			m.Sub = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 4:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Hash", wireType)
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Exp", wireType)
 			}
-			var stringLen uint64
+			m.Exp = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAccountsApi
@@ -2078,29 +5466,16 @@ func (m *Account) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				m.Exp |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthAccountsApi
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthAccountsApi
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Hash = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 5:
+		case 4:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CreatedAt", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Iat", wireType)
 			}
-			m.CreatedAt = 0
+			m.Iat = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAccountsApi
@@ -2110,16 +5485,16 @@ func (m *Account) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.CreatedAt |= int64(b&0x7F) << shift
+				m.Iat |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 6:
+		case 5:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field UpdatedAt", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Nbf", wireType)
 			}
-			m.UpdatedAt = 0
+			m.Nbf = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAccountsApi
@@ -2129,14 +5504,14 @@ func (m *Account) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.UpdatedAt |= int64(b&0x7F) << shift
+				m.Nbf |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 7:
+		case 6:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Roles", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Scope", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2164,13 +5539,13 @@ func (m *Account) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Roles = append(m.Roles, string(dAtA[iNdEx:postIndex]))
+			m.Scope = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 8:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ClientId", wireType)
 			}
-			m.Status = 0
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAccountsApi
@@ -2180,14 +5555,27 @@ func (m *Account) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Status |= AccountStatus(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 9:
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAccountsApi
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAccountsApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ClientId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 8:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ParentAccount", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Iss", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2215,64 +5603,11 @@ func (m *Account) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ParentAccount = string(dAtA[iNdEx:postIndex])
+			m.Iss = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipAccountsApi(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
-				return ErrInvalidLengthAccountsApi
-			}
-			if (iNdEx + skippy) < 0 {
-				return ErrInvalidLengthAccountsApi
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *Info) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowAccountsApi
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: Info: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Info: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+		case 9:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Aud", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2300,11 +5635,11 @@ func (m *Info) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Type = string(dAtA[iNdEx:postIndex])
+			m.Aud = append(m.Aud, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
-		case 2:
+		case 10:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Uid", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Jti", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2332,13 +5667,13 @@ func (m *Info) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Uid = string(dAtA[iNdEx:postIndex])
+			m.Jti = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TokenType", wireType)
 			}
-			m.Status = 0
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAccountsApi
@@ -2348,14 +5683,27 @@ func (m *Info) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Status |= AccountStatus(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 4:
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAccountsApi
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAccountsApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TokenType = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 12:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Roles", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2383,66 +5731,32 @@ func (m *Info) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Roles = append(m.Roles, string(dAtA[iNdEx:postIndex]))
+			m.Type = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipAccountsApi(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
-				return ErrInvalidLengthAccountsApi
-			}
-			if (iNdEx + skippy) < 0 {
-				return ErrInvalidLengthAccountsApi
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *MultiAccounts) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowAccountsApi
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
+		case 13:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			m.Status = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAccountsApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Status |= AccountStatus(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: MultiAccounts: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: MultiAccounts: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+		case 14:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Accounts", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Roles", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAccountsApi
@@ -2452,26 +5766,44 @@ func (m *MultiAccounts) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthAccountsApi
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthAccountsApi
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Accounts = append(m.Accounts, &Account{})
-			if err := m.Accounts[len(m.Accounts)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.Roles = append(m.Roles, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 15:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MustChangePwd", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAccountsApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			iNdEx = postIndex
+			m.MustChangePwd = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipAccountsApi(dAtA[iNdEx:])
@@ -2496,7 +5828,7 @@ func (m *MultiAccounts) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *AccountID) Unmarshal(dAtA []byte) error {
+func (m *TokenExchangeReq) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2519,15 +5851,15 @@ func (m *AccountID) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: AccountID: wiretype end group for non-group")
+			return fmt.Errorf("proto: TokenExchangeReq: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: AccountID: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: TokenExchangeReq: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field SubjectToken", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2555,13 +5887,13 @@ func (m *AccountID) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Id = string(dAtA[iNdEx:postIndex])
+			m.SubjectToken = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ActorToken", wireType)
 			}
-			m.Type = 0
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAccountsApi
@@ -2571,67 +5903,59 @@ func (m *AccountID) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Type |= IDType(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		default:
-			iNdEx = preIndex
-			skippy, err := skipAccountsApi(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthAccountsApi
 			}
-			if (iNdEx + skippy) < 0 {
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
 				return ErrInvalidLengthAccountsApi
 			}
-			if (iNdEx + skippy) > l {
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *AccountIDs) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowAccountsApi
+			m.ActorToken = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Audiences", wireType)
 			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAccountsApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAccountsApi
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: AccountIDs: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: AccountIDs: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAccountsApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Audiences = append(m.Audiences, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Ids", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Roles", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2659,13 +5983,13 @@ func (m *AccountIDs) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Ids = append(m.Ids, string(dAtA[iNdEx:postIndex]))
+			m.Roles = append(m.Roles, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
-		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Scopes", wireType)
 			}
-			m.Type = 0
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAccountsApi
@@ -2675,11 +5999,24 @@ func (m *AccountIDs) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Type |= IDType(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAccountsApi
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAccountsApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Scopes = append(m.Scopes, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipAccountsApi(dAtA[iNdEx:])
@@ -2704,7 +6041,7 @@ func (m *AccountIDs) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *AccountParams) Unmarshal(dAtA []byte) error {
+func (m *TokenExchangeResp) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2727,15 +6064,15 @@ func (m *AccountParams) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: AccountParams: wiretype end group for non-group")
+			return fmt.Errorf("proto: TokenExchangeResp: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: AccountParams: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: TokenExchangeResp: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Uid", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field AccessToken", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2763,11 +6100,11 @@ func (m *AccountParams) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Uid = string(dAtA[iNdEx:postIndex])
+			m.AccessToken = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Email", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field IssuedTokenType", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2795,11 +6132,11 @@ func (m *AccountParams) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Email = string(dAtA[iNdEx:postIndex])
+			m.IssuedTokenType = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Pwd", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field TokenType", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2827,11 +6164,30 @@ func (m *AccountParams) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Pwd = string(dAtA[iNdEx:postIndex])
+			m.TokenType = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExpiresIn", wireType)
+			}
+			m.ExpiresIn = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAccountsApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ExpiresIn |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Parent", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Scope", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2859,7 +6215,7 @@ func (m *AccountParams) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Parent = string(dAtA[iNdEx:postIndex])
+			m.Scope = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -2885,7 +6241,7 @@ func (m *AccountParams) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *AccountPrivileges) Unmarshal(dAtA []byte) error {
+func (m *PasswordResetReq) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2908,47 +6264,15 @@ func (m *AccountPrivileges) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: AccountPrivileges: wiretype end group for non-group")
+			return fmt.Errorf("proto: PasswordResetReq: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: AccountPrivileges: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: PasswordResetReq: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Uid", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowAccountsApi
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthAccountsApi
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthAccountsApi
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Uid = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Roles", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Email", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2976,27 +6300,8 @@ func (m *AccountPrivileges) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Roles = append(m.Roles, string(dAtA[iNdEx:postIndex]))
+			m.Email = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
-			}
-			m.Status = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowAccountsApi
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Status |= AccountStatus(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipAccountsApi(dAtA[iNdEx:])
@@ -3021,7 +6326,7 @@ func (m *AccountPrivileges) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *JwtAuthTokens) Unmarshal(dAtA []byte) error {
+func (m *CompletePasswordResetReq) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3044,15 +6349,15 @@ func (m *JwtAuthTokens) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: JwtAuthTokens: wiretype end group for non-group")
+			return fmt.Errorf("proto: CompletePasswordResetReq: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: JwtAuthTokens: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: CompletePasswordResetReq: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Access", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Token", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -3080,11 +6385,11 @@ func (m *JwtAuthTokens) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Access = string(dAtA[iNdEx:postIndex])
+			m.Token = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Refresh", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Pwd", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -3112,7 +6417,7 @@ func (m *JwtAuthTokens) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Refresh = string(dAtA[iNdEx:postIndex])
+			m.Pwd = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -3138,7 +6443,7 @@ func (m *JwtAuthTokens) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Credentials) Unmarshal(dAtA []byte) error {
+func (m *VerifyEmailReq) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3161,47 +6466,15 @@ func (m *Credentials) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Credentials: wiretype end group for non-group")
+			return fmt.Errorf("proto: VerifyEmailReq: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Credentials: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: VerifyEmailReq: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowAccountsApi
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthAccountsApi
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthAccountsApi
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Id = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Pwd", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Code", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -3229,7 +6502,7 @@ func (m *Credentials) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Pwd = string(dAtA[iNdEx:postIndex])
+			m.Code = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
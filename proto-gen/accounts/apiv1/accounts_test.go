@@ -3,6 +3,7 @@ package apiv1
 import (
 	fmt "fmt"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
@@ -51,7 +52,7 @@ func getInvalidPwdErr(err error) error {
 }
 
 func TestValidatePassword(t *testing.T) {
-	err := fmt.Errorf("invalid length: min 5, max 64 characters")
+	err := fmt.Errorf("invalid length: min 5, max 40 characters")
 	e := getInvalidPwdErr(err)
 	tests := []struct {
 		pwd string
@@ -126,3 +127,257 @@ func TestNew(t *testing.T) {
 	}
 
 }
+
+func TestHashPolicy(t *testing.T) {
+	av := DefaultValidator()
+	av.SetHashPolicy(passwords.HashPolicy{Algorithm: passwords.AlgArgon2id, Argon2id: passwords.Argon2idParams{Memory: 1024, Time: 1, Parallelism: 1, SaltLength: 16, KeyLength: 32}})
+	acc, err := av.New(&AccountParams{Email: "abc@domain.com", Pwd: "abcdefghi"})
+	if err != nil {
+		t.Fatalf("failed to create account: %v", err)
+	}
+	if alg := passwords.Algorithm(acc.Hash); alg != passwords.AlgArgon2id {
+		t.Errorf("expected a %s hash received %s", passwords.AlgArgon2id, alg)
+	}
+	if !av.Authenticate(acc, "abcdefghi") {
+		t.Errorf("password and hash dont match")
+	}
+	if av.Rehash(acc, "abcdefghi") {
+		t.Errorf("unexpected rehash of a hash following the policy")
+	}
+}
+
+func TestHashFunc(t *testing.T) {
+	argon2id := passwords.Argon2idHashFunc(passwords.Argon2idParams{Memory: 1024, Time: 1, Parallelism: 1, SaltLength: 16, KeyLength: 32})
+	tests := []struct {
+		rehash NeedsRehashFunc
+	}{
+		//no rehash func
+		{nil},
+		//a policy the hash func does not follow
+		{passwords.NeedsRehash},
+	}
+	for ind, test := range tests {
+		av := DefaultValidator()
+		av.SetHashFunc(argon2id)
+		if test.rehash != nil {
+			av.SetRehashFunc(test.rehash)
+		}
+		acc, err := av.New(&AccountParams{Email: "abc@domain.com", Pwd: "abcdefghi"})
+		if err != nil {
+			t.Fatalf("test %d: failed to create account: %v", ind, err)
+		}
+		hash := acc.Hash
+		if !av.Authenticate(acc, "abcdefghi") {
+			t.Errorf("test %d: password and hash dont match", ind)
+		}
+		if av.Rehash(acc, "abcdefghi") || acc.Hash != hash {
+			t.Errorf("test %d: unexpected rehash of a hash of the hash func", ind)
+		}
+	}
+}
+
+func TestPepper(t *testing.T) {
+	peppers := passwords.NewPeppers()
+	if err := peppers.Add("1", []byte("0123456789abcdef")); err != nil {
+		t.Fatalf("failed to add pepper: %v", err)
+	}
+	av := DefaultValidator()
+	av.SetHashFunc(peppers.HashFunc(passwords.HashAndSalt))
+	av.SetAuthnFunc(peppers.CompareFunc(passwords.CompareHashAndPassword))
+	av.SetRehashFunc(peppers.RehashFunc(passwords.NeedsRehash))
+	legacy := &Account{Hash: passwords.HashAndSalt([]byte("abcdefghi"))}
+	acc, err := av.New(&AccountParams{Email: "abc@domain.com", Pwd: "abcdefghi"})
+	if err != nil {
+		t.Fatalf("failed to create account: %v", err)
+	}
+	if !strings.HasPrefix(acc.Hash, "$pepper$v=1$2a$") {
+		t.Errorf("expected a hash peppered with version 1, received %s", acc.Hash)
+	}
+	if passwords.CompareHashAndPassword(acc.Hash, []byte("abcdefghi")) {
+		t.Errorf("peppered hash matching without the pepper")
+	}
+	if !av.Authenticate(acc, "abcdefghi") || av.Authenticate(acc, "abcdefghij") {
+		t.Errorf("unexpected authentication result with the pepper")
+	}
+	//hashes without pepper are accepted and rehashed
+	if !av.Authenticate(legacy, "abcdefghi") || !av.Rehash(legacy, "abcdefghi") || !strings.HasPrefix(legacy.Hash, "$pepper$v=1$") {
+		t.Errorf("expected legacy hash accepted and rehashed, received %s", legacy.Hash)
+	}
+	//rotation
+	if err = peppers.Add("2", []byte("fedcba9876543210")); err != nil {
+		t.Fatalf("failed to add pepper: %v", err)
+	}
+	if err = peppers.SetCurrent("2"); err != nil {
+		t.Fatalf("failed to set current pepper: %v", err)
+	}
+	if !av.Authenticate(acc, "abcdefghi") || !av.Rehash(acc, "abcdefghi") || !strings.HasPrefix(acc.Hash, "$pepper$v=2$") {
+		t.Errorf("expected hash rehashed with version 2, received %s", acc.Hash)
+	}
+	if av.Rehash(acc, "abcdefghi") {
+		t.Errorf("hash with the current pepper rehashed")
+	}
+	peppers.Remove("1")
+	if av.Authenticate(legacy, "abcdefghi") {
+		t.Errorf("hash of a removed pepper accepted")
+	}
+	if !av.Authenticate(acc, "abcdefghi") {
+		t.Errorf("hash of the current pepper rejected")
+	}
+}
+
+func TestPasswordPolicy(t *testing.T) {
+	av := DefaultValidator()
+	av.SetPasswordPolicy(passwords.Policy{MinLength: 8, Classes: []passwords.CharClass{passwords.Digit}, NoEmailLocalPart: true, NFKC: true})
+	_, err := av.New(&AccountParams{Email: "abcdef@domain.com", Pwd: "abcdef"})
+	st := status.New(codes.InvalidArgument, "invalid password")
+	st, _ = st.WithDetails(&errdetails.BadRequest{FieldViolations: []*errdetails.BadRequest_FieldViolation{
+		{Field: "pwd", Description: "invalid length: min 8 characters"},
+		{Field: "pwd", Description: "must contain a digit character"},
+		{Field: "pwd", Description: "must not contain the email"},
+	}})
+	if !reflect.DeepEqual(st.Err(), err) {
+		t.Errorf("expected %v received %v", st.Err(), err)
+	}
+	//fullwidth digits are normalized before hashing and comparing
+	acc, err := av.New(&AccountParams{Email: "abc@domain.com", Pwd: "password１"})
+	if err != nil {
+		t.Fatalf("failed to create account: %v", err)
+	}
+	if !av.Authenticate(acc, "password1") || !av.Authenticate(acc, "password１") {
+		t.Errorf("normalized password not matching")
+	}
+	//hashes of passwords stored before normalization are still accepted
+	legacy := &Account{Hash: passwords.HashAndSalt([]byte("password１"))}
+	if !av.Authenticate(legacy, "password１") {
+		t.Errorf("password hashed before normalization not matching")
+	}
+}
+
+func TestPasswordStrength(t *testing.T) {
+	av := DefaultValidator()
+	av.SetPasswordPolicy(passwords.Policy{MinScore: 3})
+	_, err := av.New(&AccountParams{Email: "john.smith@domain.com", Pwd: "Password1!"})
+	st := status.New(codes.InvalidArgument, "invalid password")
+	st, _ = st.WithDetails(&errdetails.BadRequest{FieldViolations: []*errdetails.BadRequest_FieldViolation{
+		{Field: "pwd", Description: "too weak: score 0, min 3"},
+		{Field: "pwd.hints", Description: "This is a very common password"},
+		{Field: "pwd.hints", Description: "Add another word or two. Uncommon words are better."},
+		{Field: "pwd.hints", Description: "Capitalization doesn't help very much"},
+	}})
+	if !reflect.DeepEqual(st.Err(), err) {
+		t.Errorf("expected %v received %v", st.Err(), err)
+	}
+	//the email is guessed first
+	if _, err = av.New(&AccountParams{Email: "kjh7mq2lp9zr@domain.com", Pwd: "kjH7mq2Lp9zR"}); err == nil {
+		t.Errorf("expected an error for a password made of the email")
+	}
+	if _, err = av.New(&AccountParams{Email: "john.smith@domain.com", Pwd: "kjH7mq2Lp9zR"}); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+}
+
+func TestPasswordHistory(t *testing.T) {
+	av := DefaultValidator()
+	av.SetPasswordHistory(3)
+	acc, err := av.New(&AccountParams{Email: "abc@domain.com", Pwd: "password_0"})
+	if err != nil {
+		t.Fatalf("failed to create account: %v", err)
+	}
+	reused := status.Error(codes.FailedPrecondition, "password reused")
+	te := tester.NewT(t)
+	tests := []struct {
+		pwd     string
+		err     error
+		history int
+	}{
+		{"password_0", reused, 0},
+		{"password_1", nil, 1},
+		{"password_0", reused, 1},
+		{"password_2", nil, 2},
+		{"password_3", nil, 2},
+		{"password_1", reused, 2},
+		{"password_0", nil, 2},
+		{"password_3", reused, 2},
+	}
+	for ind, test := range tests {
+		err = av.UpdatePwd(acc, test.pwd)
+		te.CheckError(ind, test.err, err)
+		if len(acc.HashHistory) != test.history {
+			t.Errorf("test %d: expected %d previous hashes, received %d", ind, test.history, len(acc.HashHistory))
+		}
+		if err == nil {
+			continue
+		}
+		pf, ok := status.Convert(err).Details()[0].(*errdetails.PreconditionFailure)
+		if !ok || pf.Violations[0].Type != ViolationPasswordReused {
+			t.Errorf("test %d: expected a %s violation, received %v", ind, ViolationPasswordReused, status.Convert(err).Details())
+		}
+	}
+	if !av.Authenticate(acc, "password_0") {
+		t.Errorf("password not updated")
+	}
+}
+
+func TestPasswordExpired(t *testing.T) {
+	now := time.Now()
+	av := DefaultValidator()
+	acc := &Account{CreatedAt: now.AddDate(0, 0, -100).Unix()}
+	if av.PasswordExpired(acc, now) {
+		t.Errorf("password expired without max age")
+	}
+	av.SetPasswordMaxAge(time.Hour * 24 * 90)
+	tests := []struct {
+		changed int64
+		expired bool
+	}{
+		{0, true},
+		{now.AddDate(0, 0, -91).Unix(), true},
+		{now.AddDate(0, 0, -89).Unix(), false},
+	}
+	for ind, test := range tests {
+		acc.PwdChangedAt = test.changed
+		if expired := av.PasswordExpired(acc, now); expired != test.expired {
+			t.Errorf("test %d: expected expired %v, received %v", ind, test.expired, expired)
+		}
+	}
+	if err := av.UpdatePwd(acc, "abcdefghi"); err != nil {
+		t.Fatalf("failed to update password: %v", err)
+	}
+	if av.PasswordExpired(acc, now) {
+		t.Errorf("password expired after update")
+	}
+}
+
+func TestStatusTransitions(t *testing.T) {
+	av := DefaultValidator()
+	tests := []struct {
+		from AccountStatus
+		to   AccountStatus
+		err  error
+	}{
+		{AccountStatus_CREATED, AccountStatus_ACTIVE, nil},
+		{AccountStatus_CREATED, AccountStatus_CREATED, nil},
+		{AccountStatus_CREATED, AccountStatus_INACTIVE, statusTransition(AccountStatus_CREATED, AccountStatus_INACTIVE)},
+		{AccountStatus_ACTIVE, AccountStatus_INACTIVE, nil},
+		{AccountStatus_ACTIVE, AccountStatus_CREATED, statusTransition(AccountStatus_ACTIVE, AccountStatus_CREATED)},
+		{AccountStatus_LOCKED, AccountStatus_ACTIVE, nil},
+		{AccountStatus_LOCKED, AccountStatus_INACTIVE, statusTransition(AccountStatus_LOCKED, AccountStatus_INACTIVE)},
+		{AccountStatus_INACTIVE, AccountStatus_ACTIVE, nil},
+		{AccountStatus_INACTIVE, AccountStatus_DELETED, nil},
+		{AccountStatus_DELETED, AccountStatus_ACTIVE, statusTransition(AccountStatus_DELETED, AccountStatus_ACTIVE)},
+		{AccountStatus_ACTIVE, AccountStatus(42), statusTransition(AccountStatus_ACTIVE, AccountStatus(42))},
+	}
+	te := tester.NewT(t)
+	for ind, test := range tests {
+		acc := &Account{Status: test.from}
+		err := av.UpdateStatus(acc, test.to)
+		te.CheckError(ind, test.err, err)
+		expected := test.to
+		if err != nil {
+			expected = test.from
+		}
+		if acc.Status != expected {
+			t.Errorf("test %d: expected status %s, received %s", ind, expected, acc.Status)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package apiv1
+
+import (
+	"fmt"
+
+	"github.com/klahssen/authn/pkg/log"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+//ViolationStatusTransition is the type of the precondition failure returned when an account can not move to a status
+const ViolationStatusTransition = "STATUS_TRANSITION"
+
+//statusTransitions lists the statuses an account can move to from each status. DELETED is final
+var statusTransitions = map[AccountStatus][]AccountStatus{
+	AccountStatus_CREATED:  {AccountStatus_ACTIVE, AccountStatus_LOCKED, AccountStatus_DELETED},
+	AccountStatus_ACTIVE:   {AccountStatus_LOCKED, AccountStatus_INACTIVE, AccountStatus_DELETED},
+	AccountStatus_LOCKED:   {AccountStatus_CREATED, AccountStatus_ACTIVE, AccountStatus_DELETED},
+	AccountStatus_INACTIVE: {AccountStatus_ACTIVE, AccountStatus_LOCKED, AccountStatus_DELETED},
+	AccountStatus_DELETED:  {},
+}
+
+//CanTransitionTo checks if an account in status s can move to status to. Staying in the same status is allowed, except for unknown statuses
+func (s AccountStatus) CanTransitionTo(to AccountStatus) bool {
+	next, ok := statusTransitions[s]
+	if !ok {
+		return false
+	}
+	if _, ok = statusTransitions[to]; !ok {
+		return false
+	}
+	if s == to {
+		return true
+	}
+	for _, st := range next {
+		if st == to {
+			return true
+		}
+	}
+	return false
+}
+
+//UpdateStatus moves an account to a status, following the transition table. Rejected transitions return a FailedPrecondition error with a ViolationStatusTransition detail
+func (av *AccountValidator) UpdateStatus(a *Account, to AccountStatus) error {
+	if !a.Status.CanTransitionTo(to) {
+		return statusTransition(a.Status, to)
+	}
+	a.Status = to
+	return nil
+}
+
+//statusTransition returns a FailedPrecondition error for a rejected transition
+func statusTransition(from, to AccountStatus) error {
+	st := status.New(codes.FailedPrecondition, "invalid status transition")
+	pf := &errdetails.PreconditionFailure{}
+	pf.Violations = append(pf.Violations, &errdetails.PreconditionFailure_Violation{
+		Type:        ViolationStatusTransition,
+		Subject:     "status",
+		Description: fmt.Sprintf("can not move from %s to %s", from, to),
+	})
+	st, err := st.WithDetails(pf)
+	if err != nil {
+		// If this errored, it will always error
+		// here, so better panic so we can figure
+		// out why than have this silently passing.
+		log.Fatalf("Unexpected error attaching metadata: %v", err)
+	}
+	return st.Err()
+}